@@ -0,0 +1,135 @@
+package bowyer_watson
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// First byte of every encoded mesh, so that the format can change later
+const binary_mesh_version = 1
+
+// Returned by UnmarshalBinary when the data ends before the value is complete
+var errShortBinary = errors.New("bowyer_watson: binary data is truncated")
+
+// Triangle method
+// Encodes the triangle as the six float64 coordinates of A, B and C in
+// little-endian order
+func (t Triangle) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 48)
+	for _, p := range [3]Point{t.A, t.B, t.C} {
+		data = appendPoint(data, p)
+	}
+	return data, nil
+}
+
+// Triangle method
+// Decodes a triangle written by MarshalBinary
+func (t *Triangle) UnmarshalBinary(data []byte) error {
+	if len(data) != 48 {
+		return fmt.Errorf("bowyer_watson: a binary triangle is 48 bytes, got %d", len(data))
+	}
+	*t = Triangle{readPoint(data[0:]), readPoint(data[16:]), readPoint(data[32:])}
+	return nil
+}
+
+// Mesh method
+// Encodes the mesh compactly: each distinct vertex is written once and triangles
+// refer to vertices by index. Edges and Neighbors are not written, since they
+// are rebuilt from the triangles when decoding.
+func (m *Mesh) MarshalBinary() ([]byte, error) {
+	vertices, faces := IndexTriangles(m.Triangles)
+
+	data := []byte{binary_mesh_version}
+	data = binary.AppendUvarint(data, uint64(len(vertices)))
+	for _, p := range vertices {
+		data = appendPoint(data, p)
+	}
+	data = binary.AppendUvarint(data, uint64(len(faces)))
+	for _, face := range faces {
+		for _, index := range face {
+			data = binary.AppendUvarint(data, uint64(index))
+		}
+	}
+	return data, nil
+}
+
+// Mesh method
+// Decodes a mesh written by MarshalBinary, rebuilding it as NewMesh would
+func (m *Mesh) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errShortBinary
+	}
+	if data[0] != binary_mesh_version {
+		return fmt.Errorf("bowyer_watson: unknown binary mesh version %d", data[0])
+	}
+	data = data[1:]
+
+	vertex_count, data, err := readCount(data, 16)
+	if err != nil {
+		return err
+	}
+	vertices := make([]Point, vertex_count)
+	for i := range vertices {
+		vertices[i] = readPoint(data)
+		data = data[16:]
+	}
+
+	triangle_count, data, err := readCount(data, 3)
+	if err != nil {
+		return err
+	}
+	triangles := make([]Triangle, triangle_count)
+	for i := range triangles {
+		var corners [3]Point
+		for k := range corners {
+			index, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errShortBinary
+			}
+			if index >= uint64(len(vertices)) {
+				return fmt.Errorf("bowyer_watson: triangle %d refers to vertex %d of %d", i, index, len(vertices))
+			}
+			corners[k] = vertices[index]
+			data = data[n:]
+		}
+		triangles[i] = Triangle{corners[0], corners[1], corners[2]}
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("bowyer_watson: %d unexpected bytes after binary mesh", len(data))
+	}
+
+	*m = *NewMesh(triangles)
+	return nil
+}
+
+// Given encoded data, append a point's coordinates to it
+func appendPoint(data []byte, p Point) []byte {
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(data, math.Float64bits(p.Y))
+}
+
+// Given at least 16 bytes written by appendPoint, decode the point
+func readPoint(data []byte) Point {
+	return Point{
+		math.Float64frombits(binary.LittleEndian.Uint64(data[0:])),
+		math.Float64frombits(binary.LittleEndian.Uint64(data[8:])),
+	}
+}
+
+// Given encoded data starting with a count of items, each taking at least size
+// bytes, decode the count
+// Return: The count and the data after it, or an error if there cannot be
+// enough data left for that many items
+func readCount(data []byte, size int) (int, []byte, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, errShortBinary
+	}
+	data = data[n:]
+	if count > uint64(len(data)/size) {
+		return 0, nil, errShortBinary
+	}
+	return int(count), data, nil
+}
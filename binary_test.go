@@ -0,0 +1,79 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Determines if two meshes have the same triangles and derived structure
+func sameMesh(m1, m2 *Mesh) bool {
+	return reflect.DeepEqual(m1.Triangles, m2.Triangles) && reflect.DeepEqual(m1.Vertices, m2.Vertices) &&
+		reflect.DeepEqual(m1.Edges, m2.Edges) && reflect.DeepEqual(m1.Neighbors, m2.Neighbors)
+}
+
+func TestMeshGobRoundTrip(t *testing.T) {
+	mesh, err := BuildMesh(append(randomPoints(23, 100), Point{-1e300, math.SmallestNonzeroFloat64}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(mesh); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Mesh
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !sameMesh(&decoded, mesh) {
+		t.Error("the mesh read back from gob differs from the one written")
+	}
+
+	triangle := Triangle{Point{1, 2}, Point{-3, 4.5}, Point{math.Pi, 1e-300}}
+	buffer.Reset()
+	if err := gob.NewEncoder(&buffer).Encode(triangle); err != nil {
+		t.Fatal(err)
+	}
+	var decoded_triangle Triangle
+	if err := gob.NewDecoder(&buffer).Decode(&decoded_triangle); err != nil || decoded_triangle != triangle {
+		t.Errorf("gob round trip of %v gave %v, %v", triangle, decoded_triangle, err)
+	}
+}
+
+func TestMeshBinaryErrors(t *testing.T) {
+	mesh, err := BuildMesh(randomPoints(24, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := mesh.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Mesh
+	if err := decoded.UnmarshalBinary(data); err != nil || !sameMesh(&decoded, mesh) {
+		t.Fatalf("UnmarshalBinary() = %v, or the mesh differs", err)
+	}
+
+	for _, length := range []int{0, 1, 5, len(data) / 2, len(data) - 1} {
+		if err := decoded.UnmarshalBinary(data[:length]); err == nil {
+			t.Errorf("UnmarshalBinary of %d of %d bytes succeeded", length, len(data))
+		}
+	}
+	if err := decoded.UnmarshalBinary(append(append([]byte(nil), data...), 0)); err == nil {
+		t.Error("UnmarshalBinary with a trailing byte succeeded")
+	}
+	versioned := append([]byte(nil), data...)
+	versioned[0] = binary_mesh_version + 1
+	if err := decoded.UnmarshalBinary(versioned); err == nil {
+		t.Error("UnmarshalBinary of an unknown version succeeded")
+	}
+
+	var triangle Triangle
+	for _, length := range []int{47, 49} {
+		if err := triangle.UnmarshalBinary(make([]byte, length)); err == nil {
+			t.Errorf("Triangle.UnmarshalBinary of %d bytes succeeded", length)
+		}
+	}
+}
@@ -4,8 +4,8 @@
 package bowyer_watson
 
 import (
+	"context"
 	"math"
-	"container/list"
 )
 
 // Basic x,y coordinate 
@@ -13,36 +13,172 @@ type Point struct {
 	X, Y float64
 }
 
+// Point method
+// Return: The Euclidean distance from p to q
+func (p Point) Distance(q Point) float64 {
+	return math.Sqrt(p.DistanceSq(q))
+}
+
+// Point method
+// Cheaper than Distance when only comparing distances
+// Return: The square of the Euclidean distance from p to q
+func (p Point) DistanceSq(q Point) float64 {
+	var dx = p.X - q.X
+	var dy = p.Y - q.Y
+	return dx*dx + dy*dy
+}
+
+// Point method
+// Return: The vector from q to p
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Point method
+// Return: The coordinate-wise sum of p and q
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Point method
+// Return: The dot product of p and q as vectors
+func (p Point) Dot(q Point) float64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
 type Triangle struct {
 	A, B, C Point
 }
 
+// Line segment between two Points
+// Edges are undirected: A and B may be given in either order, and isEqual
+// treats the two orderings as the same edge
 type Edge struct {
-	a, b Point
+	A, B Point
 }
 
 // Edge method
 // Determines if Edge, e2, is an equivalent edge
 // Return: True if equal
 func (e1 Edge) isEqual(e2 Edge) bool {
-	return (e1.a == e2.a && e1.b == e2.b || e1.a == e2.b && e1.b == e2.a)
+	return (e1.A == e2.A && e1.B == e2.B || e1.A == e2.B && e1.B == e2.A)
+}
+
+// Edge method
+// Orders the endpoints of the edge so that equivalent edges compare equal with ==
+// Return: The edge with its lexicographically smaller endpoint first
+func (e Edge) normalized() Edge {
+	if pointLess(e.B, e.A) {
+		return Edge{e.B, e.A}
+	}
+	return e
+}
+
+// Edge method
+// Return: The distance between the edge's endpoints
+func (e Edge) Length() float64 {
+	return e.A.Distance(e.B)
+}
+
+// Edge method
+// Return: The point halfway between the edge's endpoints
+func (e Edge) Midpoint() Point {
+	return Point{(e.A.X + e.B.X) / 2, (e.A.Y + e.B.Y) / 2}
+}
+
+// Triangle method
+// Determines the center of the circumcircle, the point equidistant from all 3 vertices
+// A degenerate triangle has no circumcircle, so both coordinates are NaN
+// Return: The circumcenter
+func (t Triangle) Circumcenter() Point {
+	if t.Degenerate() {
+		return Point{math.NaN(), math.NaN()}
+	}
+
+	// Working relative to A keeps the squares small, so points far from the
+	// origin do not lose their precision to cancellation
+	var bx, by = t.B.X - t.A.X, t.B.Y - t.A.Y
+	var cx, cy = t.C.X - t.A.X, t.C.Y - t.A.Y
+	var b_sq = bx*bx + by*by
+	var c_sq = cx*cx + cy*cy
+	var d = 2 * (bx*cy - by*cx)
+
+	var circum_x = t.A.X + (cy*b_sq-by*c_sq)/d
+	var circum_y = t.A.Y + (bx*c_sq-cx*b_sq)/d
+
+	return Point{circum_x, circum_y}
+}
+
+// Triangle method
+// Determines the radius of the circumcircle
+// A degenerate triangle has no circumcircle, so its radius is +Inf
+// Return: The circumradius
+func (t Triangle) CircumRadius() float64 {
+	if t.Degenerate() {
+		return math.Inf(1)
+	}
+
+	var center = t.Circumcenter()
+	return t.A.Distance(center)
+}
+
+// Triangle method
+// Determines the inscribed circle, the largest circle inside the triangle
+// The center is the average of the vertices, each weighted by the length of the
+// side opposite it, and the radius is the area over half the perimeter. A
+// degenerate triangle gets a radius of zero or nearly zero, and a triangle whose
+// vertices are all the same point gets that point and zero
+// Return: The center and radius of the incircle
+func (t Triangle) Incircle() (Point, float64) {
+	var a = t.B.Distance(t.C)
+	var b = t.C.Distance(t.A)
+	var c = t.A.Distance(t.B)
+	var perimeter = a + b + c
+	if perimeter == 0 {
+		return t.A, 0
+	}
+
+	var center = Point{
+		(a*t.A.X + b*t.B.X + c*t.C.X) / perimeter,
+		(a*t.A.Y + b*t.B.Y + c*t.C.Y) / perimeter,
+	}
+	return center, t.Area() / (perimeter / 2)
 }
 
 // Triangle method
 // Determines if a given Point is contained within the circumcircle of the triangle
 // A circumcircle is the circle whose circumference contains all 3 vertices of a triangle
+// Points on the circumference count as contained. The test uses exact predicates
+// rather than the circumcenter and radius, so it never misclassifies a point
+// because of round-off, however close to the circle it is.
+// Triangles whose vertices are exactly collinear have no circumcircle, so they
+// contain no points, and neither does a triangle or point with a coordinate that
+// is not finite
 // Return: True if point is contained
 func (t Triangle) CircumcircleContains(p Point) bool {
-	var ab = math.Pow(t.A.X, 2) + math.Pow(t.A.Y, 2)
-	var cd = math.Pow(t.B.X, 2) + math.Pow(t.B.Y, 2)
-	var ef = math.Pow(t.C.X, 2) + math.Pow(t.C.Y, 2)
+	return robustCircumcircleContains(t, p)
+}
 
-	var circum_x = (ab * (t.C.Y - t.B.Y) + cd * (t.A.Y - t.C.Y) + ef * (t.B.Y - t.A.Y)) / (t.A.X * (t.C.Y - t.B.Y) + t.B.X * (t.A.Y - t.C.Y) + t.C.X * (t.B.Y - t.A.Y)) / 2
-	var circum_y = (ab * (t.C.X - t.B.X) + cd * (t.A.X - t.C.X) + ef * (t.B.X - t.A.X)) / (t.A.Y * (t.C.X - t.B.X) + t.B.Y * (t.A.X - t.C.X) + t.C.Y * (t.B.X - t.A.X)) / 2
-	var circum_radius = math.Sqrt(math.Pow(t.A.X - circum_x, 2) + math.Pow(t.A.Y - circum_y, 2))
+// Relative size, compared to the longest side squared, below which twice the
+// area of a triangle is treated as zero
+const degenerate_tolerance = 1e-12
 
-	var dist = math.Sqrt(math.Pow(p.X - circum_x, 2) + math.Pow(p.Y - circum_y, 2))
-	return dist <= circum_radius
+// Triangle method
+// Determines if the triangle's vertices are collinear (or coincident), in which
+// case it has no area and no circumcircle
+// Thin triangles are not degenerate as long as their area is large relative to
+// the round-off expected from the length of their sides
+// Return: True if the triangle is degenerate
+func (t Triangle) Degenerate() bool {
+	var cross = 2 * t.SignedArea()
+
+	return math.Abs(cross) <= degenerate_tolerance * t.longestSideSq()
+}
+
+// Triangle method
+// Return: The square of the length of the triangle's longest side
+func (t Triangle) longestSideSq() float64 {
+	return math.Max(t.A.DistanceSq(t.B), math.Max(t.B.DistanceSq(t.C), t.C.DistanceSq(t.A)))
 }
 
 // Triangle method
@@ -52,92 +188,272 @@ func (t Triangle) ContainsPoint(p Point) bool {
 	return t.A == p || t.B == p || t.C == p
 }
 
-// Given an array of points, return an array of triangles of the triangulation
-// Super triangle is a triangle that contains all the points
-// Source for algorithm: paulbourke.net/papers/triangulate
-func DelaunayTriangulation(points []Point, super_triangle Triangle) []Triangle {
-	triangle_list := list.New()
-	triangle_list.PushBack(super_triangle)
+// Triangle method
+// Determines if two triangles have the same vertices, in any order and either
+// winding, unlike == which needs each vertex in the same place. Only which points
+// appear is compared, so a degenerate triangle repeating a vertex equals any
+// other using the same points.
+// Return: True if every vertex of each triangle is a vertex of the other
+func (t Triangle) Equal(other Triangle) bool {
+	return other.ContainsPoint(t.A) && other.ContainsPoint(t.B) && other.ContainsPoint(t.C) &&
+		t.ContainsPoint(other.A) && t.ContainsPoint(other.B) && t.ContainsPoint(other.C)
+}
 
-	for _, p := range points {
-		edge_list := list.New()
-		remove_triangles := list.New()
+// Triangle method
+// Determines if a given Point lies inside the triangle, using Orient2D for the side
+// of each edge the point falls on. Points exactly on an edge or a vertex count as inside, so
+// that every point of a triangulated region is inside at least one triangle.
+// A degenerate triangle contains no points
+// Return: True if point is contained
+func (t Triangle) Contains(p Point) bool {
+	if t.Degenerate() {
+		return false
+	}
+
+	var ab = Orient2D(t.A, t.B, p)
+	var bc = Orient2D(t.B, t.C, p)
+	var ca = Orient2D(t.C, t.A, p)
+
+	return ab >= 0 && bc >= 0 && ca >= 0 || ab <= 0 && bc <= 0 && ca <= 0
+}
 
-		for itr := triangle_list.Front(); itr != nil; itr = itr.Next() {
-			if itr.Value.(Triangle).CircumcircleContains(p) {
-				triangle := itr.Value.(Triangle)
+// Triangle method
+// Determines the area of the triangle using the shoelace formula
+// The sign reveals the orientation of the vertices
+// Return: Positive when A, B, C are counter-clockwise, negative when clockwise
+func (t Triangle) SignedArea() float64 {
+	return ((t.B.X - t.A.X) * (t.C.Y - t.A.Y) - (t.B.Y - t.A.Y) * (t.C.X - t.A.X)) / 2
+}
 
-				var new_edge [3]Edge
+// Triangle method
+// Determines the area of the triangle, regardless of the orientation of its vertices
+// Return: The area, or 0 for a degenerate triangle
+func (t Triangle) Area() float64 {
+	if t.Degenerate() {
+		return 0
+	}
+	return math.Abs(Orient2D(t.A, t.B, t.C)) / 2
+}
 
-				new_edge[0] = Edge{triangle.A, triangle.B}
-				new_edge[1] = Edge{triangle.A, triangle.C}
-				new_edge[2] = Edge{triangle.B, triangle.C}
+// Triangle method
+// Determines the centroid, the average of the three vertices
+// Return: The centroid
+func (t Triangle) Centroid() Point {
+	return Point{(t.A.X + t.B.X + t.C.X) / 3, (t.A.Y + t.B.Y + t.C.Y) / 3}
+}
 
-				remove_triangles.PushBack(itr)
+// How far the super triangle extends past the points, as a multiple of the
+// larger side of their bounding box
+// The circumcircles of thin triangles along the convex hull can reach a super
+// triangle that is too close, which loses those triangles, so it is kept far away.
+const super_triangle_margin = 1000
 
-				for i := 0; i < 3; i++ {
-					edge_list.PushBack(new_edge[i])
-				}
-			}
-		}
+// Smallest side of the box the super triangle is built around, relative to the
+// largest coordinate, so that its corners stay distinct from the points in
+// floating point
+const super_triangle_min_extent = 1e-9
 
-		for itr := remove_triangles.Front(); itr != nil; itr = itr.Next() {
-			// The iterator points to an element, so dereference and remove from list
-			triangle_list.Remove(itr.Value.(*list.Element))
-		}
+// Given an array of points, return a triangle that strictly contains all of them
+// The triangle is built around the bounding box of the points. When the box has
+// no extent (a single point, or identical points) a unit box is used instead so
+// that the result is never degenerate.
+// Return: A super triangle suitable for DelaunayTriangulation
+func ComputeSuperTriangle(points []Point) Triangle {
+	return ComputeSuperTriangleWithMargin(points, super_triangle_margin)
+}
 
-		remove_edges := list.New()
-		for itr := edge_list.Front(); itr != nil; itr = itr.Next() {
+// Given an array of points and a margin, return a triangle that strictly contains
+// all of them, extending margin times the larger side of their bounding box past it
+// The default used by ComputeSuperTriangle is 1000. A margin of zero or less
+// uses the default.
+// The box is built on its larger side, so a thin or zero-height box, as for
+// nearly collinear points, still gives a well-shaped triangle. A box too small to
+// be told apart from the points in floating point is inflated first, and a box
+// with no extent at all uses a unit box.
+// Return: A super triangle suitable for DelaunayTriangulation
+func ComputeSuperTriangleWithMargin(points []Point, margin float64) Triangle {
+	if margin <= 0 {
+		margin = super_triangle_margin
+	}
+	min, max := BoundingBox(points)
 
-			left := itr
-			if itr.Next() == nil {
-				break
-			}
-			right := itr.Next()
-			if left.Value.(Edge).isEqual(right.Value.(Edge)) {
-				// Push the *Element onto the list
-				remove_edges.PushBack(left)
-				remove_edges.PushBack(right)
-			}
+	delta := math.Max(max.X-min.X, max.Y-min.Y)
+	magnitude := math.Max(math.Max(math.Abs(min.X), math.Abs(max.X)), math.Max(math.Abs(min.Y), math.Abs(max.Y)))
+	delta = math.Max(delta, super_triangle_min_extent*magnitude)
+	if delta == 0 {
+		delta = 1
+	}
 
-		}
+	mid_x := (min.X + max.X) / 2
+	mid_y := (min.Y + max.Y) / 2
 
-		for itr := remove_edges.Front(); itr != nil; itr = itr.Next() {
-			// The iterator points to an element, so dereference and remove from list
-			edge_list.Remove(itr.Value.(*list.Element))
-		}
+	return Triangle{
+		Point{mid_x - margin*delta, mid_y - delta},
+		Point{mid_x, mid_y + margin*delta},
+		Point{mid_x + margin*delta, mid_y - delta},
+	}
+}
+
+// An axis-aligned rectangle, the points with X between Min.X and Max.X and Y between
+// Min.Y and Max.Y
+type Rect struct {
+	Min, Max Point
+}
+
+// Rect method
+// Return: The corners of the rectangle counter-clockwise, starting from Min
+func (r Rect) corners() []Point {
+	return []Point{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}
+}
 
-		for itr := edge_list.Front(); itr != nil; itr = itr.Next() {
-			new_triangle := Triangle{itr.Value.(Edge).a, itr.Value.(Edge).b, p}
-			triangle_list.PushBack(new_triangle)
+// Given an array of points, return the corners of their axis-aligned bounding box
+// A single point is its own bounding box, with min and max equal
+// Return: The minimum and maximum coordinates, both the zero Point for an empty array
+func BoundingBox(points []Point) (min, max Point) {
+	for i, p := range points {
+		if i == 0 || p.X < min.X {
+			min.X = p.X
+		}
+		if i == 0 || p.Y < min.Y {
+			min.Y = p.Y
+		}
+		if i == 0 || p.X > max.X {
+			max.X = p.X
+		}
+		if i == 0 || p.Y > max.Y {
+			max.Y = p.Y
 		}
 	}
+	return min, max
+}
 
-	remove_triangles := list.New()
+// Given an array of points, return an array of triangles of the triangulation
+// Super triangle is a triangle that contains all the points, see ComputeSuperTriangle
+// Points that appear more than once are only inserted the first time they are
+// seen, so the result is the same as triangulating the distinct points
+// Source for algorithm: paulbourke.net/papers/triangulate
+func DelaunayTriangulation(points []Point, super_triangle Triangle) []Triangle {
+	return RemoveSuperTriangle(insertPoints(points, super_triangle, Options{}), super_triangle)
+}
 
+// Given every triangle of a triangulation, remove any that use a Point of the
+// super triangle
+// This is the last step of DelaunayTriangulation and Triangulation.Triangles, for
+// callers managing their own super triangle, as with Triangulation.AllTriangles.
+// Return: The remaining triangles, in their original order, reusing the storage of
+// triangles, whose contents are overwritten
+func RemoveSuperTriangle(triangles []Triangle, super_triangle Triangle) []Triangle {
 	//Remove any triangles using the Points of the supertriangle
-	for itr := triangle_list.Front(); itr != nil; itr = itr.Next() {
-		if itr.Value.(Triangle).ContainsPoint(super_triangle.A) ||
-		   itr.Value.(Triangle).ContainsPoint(super_triangle.B) ||
-		   itr.Value.(Triangle).ContainsPoint(super_triangle.C) {	
-	   		
-	   	    // Push the *Element onto the list
-	   	    remove_triangles.PushBack(itr)
-		} 
+	kept := triangles[:0]
+	for _, triangle := range triangles {
+		if triangle.ContainsPoint(super_triangle.A) ||
+			triangle.ContainsPoint(super_triangle.B) ||
+			triangle.ContainsPoint(super_triangle.C) {
+			continue
+		}
+		kept = append(kept, triangle)
 	}
 
-	for itr := remove_triangles.Front(); itr != nil; itr = itr.Next() {
-		// The iterator points to an element, so dereference and remove from list
-		triangle_list.Remove(itr.Value.(*list.Element))
+	return kept
+}
+
+// Given an array of points, return an array of triangles of the triangulation
+// Like DelaunayTriangulation, but the points are first checked with ValidatePoints
+// Return: The triangles, or an error describing why the points were rejected
+func DelaunayTriangulationChecked(points []Point, super_triangle Triangle) ([]Triangle, error) {
+	if err := ValidatePoints(points); err != nil {
+		return nil, err
 	}
+	return DelaunayTriangulation(points, super_triangle), nil
+}
+
+// Given an array of points, return an array of triangles of the triangulation
+// The super triangle is computed from the points with ComputeSuperTriangle. In the
+// rare case that it is still close enough to lose some of the thin triangles
+// along the convex hull, the points are triangulated again with
+// TriangulateDivideConquer, so the triangles always cover the convex hull of the
+// points.
+// Return: The triangles, or an error describing why the points were rejected
+func Triangulate(points []Point) ([]Triangle, error) {
+	return triangulate(context.Background(), points, Options{})
+}
+
+// A Triangle alongside its circumcircle, so that the circumcircle is computed
+// once when the triangle is created rather than on every point insertion
+type circumTriangle struct {
+	triangle  Triangle
+	center    Point
+	radius_sq float64
+	// Points whose squared distance from center is within slack of radius_sq
+	// are too close to the circle to trust the cached values, and are tested
+	// with the exact predicates instead
+	slack float64
+}
 
-	return_triangles := make([]Triangle, triangle_list.Len(), triangle_list.Len())
+// Relative error allowed in a well-shaped triangle's cached circumcircle. It is
+// scaled up for thin triangles, whose circumcenters are less accurate, and an
+// allowance is added for rounding the circumcenter to the precision of the
+// coordinates, which matters for points far from the origin.
+const circumcircle_slack = 1e-10
 
-	i := 0
-	for itr := triangle_list.Front(); itr != nil; itr = itr.Next() {
-		return_triangles[i] = itr.Value.(Triangle)
-		i++
+// Given a triangle, compute its circumcircle
+// Exactly collinear triangles get a negative radius so that they contain no points
+func newCircumTriangle(t Triangle) circumTriangle {
+	var cross = Orient2D(t.A, t.B, t.C)
+	if cross == 0 {
+		return circumTriangle{t, Point{}, -1, 0}
 	}
+	if t.Degenerate() {
+		// Too thin for a meaningful circumcenter, so always test exactly
+		return circumTriangle{t, Point{}, 0, math.Inf(1)}
+	}
+
+	var center = t.Circumcenter()
+	var radius_sq = t.A.DistanceSq(center)
 
-	return return_triangles
+	var magnitude = math.Max(math.Abs(center.X), math.Abs(center.Y))
+	for _, p := range [3]Point{t.A, t.B, t.C} {
+		magnitude = math.Max(magnitude, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+	}
+	var slack = circumcircle_slack*radius_sq*t.longestSideSq()/math.Abs(cross) +
+		16*epsilon*magnitude*math.Sqrt(radius_sq)
+
+	return circumTriangle{t, center, radius_sq, slack}
+}
+
+// circumTriangle method
+// Determines if a given Point is contained within the cached circumcircle
+// Compares squared distances to avoid a math.Sqrt per test, and falls back to the
+// exact test of CircumcircleContains for points close to the circle.
+// A positive tolerance instead accepts points up to tolerance outside the circle.
+// Return: True if point is contained
+func (c circumTriangle) contains(p Point, tolerance float64) bool {
+	if c.radius_sq < 0 {
+		return false
+	}
+	if tolerance > 0 && !math.IsInf(c.slack, 1) {
+		return p.Distance(c.center) <= math.Sqrt(c.radius_sq)+tolerance
+	}
+	if !math.IsInf(c.slack, 1) {
+		var diff = p.DistanceSq(c.center) - c.radius_sq
+		if diff > c.slack {
+			return false
+		}
+		if diff < -c.slack {
+			return true
+		}
+	}
+	return robustCircumcircleContains(c.triangle, p)
+}
+
+// Given an array of points, insert each one into a triangulation that starts as
+// just the super triangle
+// Points that appear more than once are only inserted the first time they are seen
+// Return: Every Triangle, including those using the super triangle's Points
+func insertPoints(points []Point, super_triangle Triangle, opts Options) []Triangle {
+	triangulation := newTriangulation(super_triangle, opts)
+	for _, p := range points {
+		triangulation.Insert(p)
+	}
+	return triangulation.AllTriangles()
 }
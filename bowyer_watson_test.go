@@ -0,0 +1,737 @@
+package bowyer_watson
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Return: n points drawn uniformly from the unit square with a fixed seed
+func randomPoints(seed int64, n int) []Point {
+	r := rand.New(rand.NewSource(seed))
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{r.Float64(), r.Float64()}
+	}
+	return points
+}
+
+// Return: The points x, y for x and y in 0 to n-1
+func latticePoints(n int) []Point {
+	var points []Point
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			points = append(points, Point{float64(x), float64(y)})
+		}
+	}
+	return points
+}
+
+// Return: The set of normalized edges of the triangles
+func edgeSet(triangles []Triangle) map[Edge]bool {
+	edges := make(map[Edge]bool)
+	for _, t := range triangles {
+		edges[Edge{t.A, t.B}.normalized()] = true
+		edges[Edge{t.B, t.C}.normalized()] = true
+		edges[Edge{t.C, t.A}.normalized()] = true
+	}
+	return edges
+}
+
+func TestEpsilonIncludesMarginalPoint(t *testing.T) {
+	// d is about 7e-7 outside the circumcircle of a, b and c
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{0, 2}, Point{2, 2 + 1e-6}
+	points := []Point{a, b, c, d}
+
+	exact, err := TriangulateWithOptions(points, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edges := edgeSet(exact); !edges[Edge{b, c}.normalized()] || edges[Edge{a, d}.normalized()] {
+		t.Errorf("without Epsilon got %v, want the diagonal b-c", exact)
+	}
+
+	tolerant, err := TriangulateWithOptions(points, Options{Epsilon: 1e-5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edges := edgeSet(tolerant); !edges[Edge{a, d}.normalized()] || edges[Edge{b, c}.normalized()] {
+		t.Errorf("with Epsilon 1e-5 got %v, want the diagonal a-d", tolerant)
+	}
+
+	tight, err := TriangulateWithOptions(points, Options{Epsilon: 1e-8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edges := edgeSet(tight); !edges[Edge{b, c}.normalized()] {
+		t.Errorf("with Epsilon 1e-8 got %v, want the diagonal b-c", tight)
+	}
+}
+
+func TestComputeSuperTriangleContainsPoints(t *testing.T) {
+	tests := [][]Point{
+		{{3, 4}},
+		{{1, 1}, {1, 1}, {1, 1}},
+		{{0, 0}, {1e6, 0}, {5e5, 1e-6}},
+		{{-1e9, 1e9}, {1e9 + 1, 1e9 + 2}},
+		randomPoints(1, 100),
+	}
+	for _, points := range tests {
+		super := ComputeSuperTriangle(points)
+		if super.Degenerate() {
+			t.Errorf("ComputeSuperTriangle(%v) = %v is degenerate", points, super)
+			continue
+		}
+		for _, p := range points {
+			on_edge := Orient2D(super.A, super.B, p) == 0 || Orient2D(super.B, super.C, p) == 0 ||
+				Orient2D(super.C, super.A, p) == 0
+			if !super.Contains(p) || on_edge {
+				t.Errorf("ComputeSuperTriangle(%v) = %v does not strictly contain %v", points, super, p)
+			}
+		}
+	}
+}
+
+// Determines if the triangles are a valid Delaunay triangulation covering the
+// convex hull of the points
+// Return: nil if they are, otherwise a description of the problem
+func checkCoversHull(triangles []Triangle, points []Point) error {
+	if err := Validate(triangles); err != nil {
+		return err
+	}
+	return checkHullArea(triangles, points)
+}
+
+// Determines if the triangles' areas add up to the area of the convex hull of the
+// points, as they do when the triangles cover the hull without overlapping
+// Return: nil if they do, otherwise a description of the problem
+func checkHullArea(triangles []Triangle, points []Point) error {
+	hull := PolygonArea(ConvexHullOfPoints(points))
+	if area := TotalArea(triangles); math.Abs(area-hull) > 1e-9*hull {
+		return fmt.Errorf("%d triangles cover %v, hull is %v", len(triangles), area, hull)
+	}
+	return nil
+}
+
+func TestTriangulateCoversHull(t *testing.T) {
+	var tests [][]Point
+	tests = append(tests, []Point{
+		{0.6972329201785297, 0.27050688611222345},
+		{0.17172897740330403, 0.11409952732724175},
+		{0.8653237246125277, 0.3288736341203201},
+	})
+	for seed := int64(0); seed < 10000; seed++ {
+		tests = append(tests, randomPoints(seed, 3))
+	}
+	for seed := int64(0); seed < 300; seed++ {
+		points := randomPoints(seed, 30)
+		if seed%3 == 0 {
+			points = append(points, latticePoints(4)...)
+		}
+		tests = append(tests, points)
+	}
+
+	var triangulator Triangulator
+	for i, points := range tests {
+		if ValidatePoints(points) != nil {
+			continue
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Fatalf("test %d: Triangulate(%v): %v", i, points, err)
+		}
+
+		reused, err := triangulator.Triangulate(points)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if err := checkCoversHull(reused, points); err != nil {
+			t.Fatalf("test %d: Triangulator.Triangulate(%v): %v", i, points, err)
+		}
+	}
+}
+
+func TestTriangulateNearlyCollinear(t *testing.T) {
+	tests := map[string][]Point{}
+	for _, offset := range []float64{0, 1e6} {
+		var points []Point
+		for i := 0; i < 40; i++ {
+			points = append(points, Point{offset + float64(i), offset + 1e-7*float64(i*i%7-3)})
+		}
+		tests[fmt.Sprintf("horizontal at %g", offset)] = points
+
+		var turned []Point
+		for _, p := range points {
+			turned = append(turned, Point{p.Y, p.X})
+		}
+		tests[fmt.Sprintf("vertical at %g", offset)] = turned
+	}
+
+	for name, points := range tests {
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if vertices, _ := IndexTriangles(triangles); len(vertices) != len(points) {
+			t.Errorf("%s: %d of %d points are used", name, len(vertices), len(points))
+		}
+	}
+}
+
+func TestComputeSuperTriangleWithMargin(t *testing.T) {
+	points := []Point{{0, 0}, {10, 0}, {5, 1e-9}}
+	if got, want := ComputeSuperTriangleWithMargin(points, 0), ComputeSuperTriangle(points); got != want {
+		t.Errorf("margin 0 gave %v, want the default %v", got, want)
+	}
+	for _, margin := range []float64{2, 10, 1e6} {
+		super := ComputeSuperTriangleWithMargin(points, margin)
+		for _, p := range points {
+			if !super.Contains(p) {
+				t.Errorf("margin %v: %v does not contain %v", margin, super, p)
+			}
+		}
+		if width := super.C.X - super.A.X; width != 2*margin*10 {
+			t.Errorf("margin %v: the super triangle is %v wide, want %v", margin, width, 2*margin*10)
+		}
+	}
+}
+
+func TestCompleteDetectsLostHullTriangles(t *testing.T) {
+	lost := 0
+	for seed := int64(0); seed < 100; seed++ {
+		points := randomPoints(seed, 30)
+		// A super triangle this close loses many hull triangles
+		triangulation := NewTriangulation(ComputeSuperTriangleWithMargin(points, 2))
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		triangles := triangulation.Triangles()
+		if !triangulation.complete(triangles) {
+			lost++
+			continue
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Errorf("seed %d: complete() but %v", seed, err)
+		}
+	}
+	if lost == 0 {
+		t.Error("no triangulation was missing hull triangles")
+	}
+}
+
+// Calls visit with every ordering of the points
+func permutations(points []Point, visit func([]Point)) {
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(points) {
+			visit(points)
+			return
+		}
+		for i := k; i < len(points); i++ {
+			points[k], points[i] = points[i], points[k]
+			permute(k + 1)
+			points[k], points[i] = points[i], points[k]
+		}
+	}
+	permute(0)
+}
+
+func TestDelaunayTriangulationSharedEdges(t *testing.T) {
+	// The centre is inside the circumcircle of every triangle of the pentagon, so
+	// inserting it last makes a cavity whose shared edges are not next to each
+	// other in the list of bad triangles
+	points := []Point{{0, 0}, {4, 0}, {5, 3}, {2, 5}, {-1, 3}, {2, 2}}
+	want := edgeSet(referenceTriangulate(points))
+
+	permutations(points, func(order []Point) {
+		triangles := DelaunayTriangulation(order, ComputeSuperTriangle(order))
+		if err := checkCoversHull(triangles, order); err != nil {
+			t.Fatalf("DelaunayTriangulation(%v): %v", order, err)
+		}
+		if edge, ok := matchesReference(triangles, true, want); !ok {
+			t.Fatalf("DelaunayTriangulation(%v) differs from the reference at %v", order, edge)
+		}
+	})
+}
+
+func TestDuplicatePoints(t *testing.T) {
+	unique := []Point{{0, 0}, {1, 0}, {0, 1}}
+	repeated := []Point{{0, 0}, {1, 0}, {0, 1}, {0, 0}}
+
+	super := ComputeSuperTriangle(unique)
+	got := DelaunayTriangulation(repeated, super)
+	if want := DelaunayTriangulation(unique, super); !reflect.DeepEqual(got, want) {
+		t.Errorf("DelaunayTriangulation(%v) = %v, want %v", repeated, got, want)
+	}
+
+	got, err := Triangulate(repeated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := Triangulate(unique); !reflect.DeepEqual(got, want) {
+		t.Errorf("Triangulate(%v) = %v, want %v", repeated, got, want)
+	}
+}
+
+func TestCircumcircleContainsCollinear(t *testing.T) {
+	collinear := []Triangle{
+		{Point{0, 0}, Point{1, 1}, Point{2, 2}},
+		{Point{0, 0}, Point{2, 0}, Point{1, 0}},
+		{Point{1, 1}, Point{1, 1}, Point{1, 1}},
+		{Point{1e9, 1e9}, Point{1e9 + 1, 1e9 + 1}, Point{1e9 + 3, 1e9 + 3}},
+	}
+	probes := []Point{{0, 0}, {1, 1}, {0.5, 0.5}, {1, 0}, {-5, 7}, {1e9 + 2, 1e9 + 2}, {math.MaxFloat64, 0}}
+	for _, triangle := range collinear {
+		if !triangle.Degenerate() {
+			t.Errorf("%v is not Degenerate", triangle)
+		}
+		for _, p := range probes {
+			if triangle.CircumcircleContains(p) {
+				t.Errorf("collinear %v contains %v", triangle, p)
+			}
+		}
+	}
+
+	// Thin but valid triangles still have a circumcircle
+	thin := Triangle{Point{0, 0}, Point{1, 0}, Point{0.5, 1e-6}}
+	if thin.Degenerate() {
+		t.Errorf("%v is Degenerate", thin)
+	}
+	if !thin.CircumcircleContains(thin.Centroid()) || thin.CircumcircleContains(Point{0.5, 1}) {
+		t.Errorf("%v has the wrong circumcircle", thin)
+	}
+}
+
+func TestCircumcenter(t *testing.T) {
+	tests := []struct {
+		triangle Triangle
+		center   Point
+		radius   float64
+	}{
+		{Triangle{Point{0, 0}, Point{2, 0}, Point{0, 2}}, Point{1, 1}, math.Sqrt2},
+		{Triangle{Point{0, 0}, Point{0, 4}, Point{3, 0}}, Point{1.5, 2}, 2.5},
+		{Triangle{Point{-1, 0}, Point{1, 0}, Point{0, math.Sqrt(3)}}, Point{0, 1 / math.Sqrt(3)}, 2 / math.Sqrt(3)},
+		{Triangle{Point{5, 5}, Point{6, 5}, Point{5, 6}}, Point{5.5, 5.5}, math.Sqrt(0.5)},
+		{Triangle{Point{1e6, 1e6}, Point{1e6 + 1, 1e6}, Point{1e6, 1e6 + 1}}, Point{1e6 + 0.5, 1e6 + 0.5}, math.Sqrt(0.5)},
+	}
+	for _, test := range tests {
+		center, radius := test.triangle.Circumcenter(), test.triangle.CircumRadius()
+		if center.Distance(test.center) > 1e-9 || math.Abs(radius-test.radius) > 1e-9 {
+			t.Errorf("%v: got center %v radius %v, want %v %v", test.triangle, center, radius, test.center, test.radius)
+		}
+		for _, vertex := range []Point{test.triangle.A, test.triangle.B, test.triangle.C} {
+			if d := vertex.Distance(center); math.Abs(d-radius) > 1e-9 {
+				t.Errorf("%v: vertex %v is %v from the circumcenter, want %v", test.triangle, vertex, d, radius)
+			}
+		}
+	}
+
+	degenerate := Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}}
+	if center := degenerate.Circumcenter(); !math.IsNaN(center.X) || !math.IsNaN(center.Y) {
+		t.Errorf("degenerate Circumcenter() = %v, want NaN", center)
+	}
+	if radius := degenerate.CircumRadius(); !math.IsInf(radius, 1) {
+		t.Errorf("degenerate CircumRadius() = %v, want +Inf", radius)
+	}
+}
+
+// Given an array of points, return their triangulation using the container/list
+// loop DelaunayTriangulation had before it moved to slices
+// Kept to check that the slices give the same triangles, and to benchmark against
+func listTriangulate(points []Point, super_triangle Triangle) []Triangle {
+	triangle_list := list.New()
+	triangle_list.PushBack(super_triangle)
+
+	for _, p := range points {
+		edge_list := list.New()
+		for itr := triangle_list.Front(); itr != nil; {
+			next := itr.Next()
+			if triangle := itr.Value.(Triangle); triangle.CircumcircleContains(p) {
+				edge_list.PushBack(Edge{triangle.A, triangle.B})
+				edge_list.PushBack(Edge{triangle.A, triangle.C})
+				edge_list.PushBack(Edge{triangle.B, triangle.C})
+				triangle_list.Remove(itr)
+			}
+			itr = next
+		}
+
+		edge_count := make(map[Edge]int, edge_list.Len())
+		for itr := edge_list.Front(); itr != nil; itr = itr.Next() {
+			edge_count[itr.Value.(Edge).normalized()]++
+		}
+		for itr := edge_list.Front(); itr != nil; itr = itr.Next() {
+			if edge := itr.Value.(Edge); edge_count[edge.normalized()] == 1 {
+				triangle_list.PushBack(Triangle{edge.A, edge.B, p})
+			}
+		}
+	}
+
+	var triangles []Triangle
+	for itr := triangle_list.Front(); itr != nil; itr = itr.Next() {
+		triangles = append(triangles, itr.Value.(Triangle))
+	}
+	return RemoveSuperTriangle(triangles, super_triangle)
+}
+
+func TestDelaunayTriangulationMatchesList(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		points := randomPoints(seed, 200)
+		super := ComputeSuperTriangle(points)
+		got := DelaunayTriangulation(points, super)
+		want := listTriangulate(points, super)
+		if len(got) != len(want) {
+			t.Fatalf("seed %d: got %d triangles, want %d", seed, len(got), len(want))
+		}
+		if edge, ok := matchesReference(got, true, edgeSet(want)); !ok {
+			t.Fatalf("seed %d: the triangulations differ at %v", seed, edge)
+		}
+	}
+}
+
+func benchmarkDelaunayTriangulation(b *testing.B, n int, triangulate func([]Point, Triangle) []Triangle) {
+	points := randomPoints(1, n)
+	super := ComputeSuperTriangle(points)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		triangulate(points, super)
+	}
+}
+
+func BenchmarkDelaunayTriangulation1k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 1000, DelaunayTriangulation)
+}
+
+func BenchmarkListTriangulate1k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 1000, listTriangulate)
+}
+
+func BenchmarkDelaunayTriangulation10k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 10000, DelaunayTriangulation)
+}
+
+func BenchmarkListTriangulate10k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 10000, listTriangulate)
+}
+
+func TestCachedCircumcircleMatches(t *testing.T) {
+	points := randomPoints(5, 300)
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Points near the circles as well as random ones, and thin triangles
+	probes := append(randomPoints(6, 300), Point{0.5, 1e-17})
+	for _, triangle := range triangles {
+		probes = append(probes, triangle.A, triangle.Circumcenter().Add(Point{triangle.CircumRadius(), 0}))
+	}
+	triangles = append(triangles, Triangle{Point{0, 0}, Point{1, 0}, Point{0.5, 1e-16}},
+		Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}})
+
+	for _, triangle := range triangles {
+		cached := newCircumTriangle(triangle)
+		for _, p := range probes {
+			if got, want := cached.contains(p, 0), triangle.CircumcircleContains(p); got != want {
+				t.Fatalf("cached circle of %v contains %v = %v, want %v", triangle, p, got, want)
+			}
+		}
+	}
+}
+
+// Return: The triangles of n random points, each with its circumcircle cached,
+// and random points to test against them
+func benchmarkCircles(n int) ([]circumTriangle, []Point) {
+	points := randomPoints(1, n)
+	triangles := DelaunayTriangulation(points, ComputeSuperTriangle(points))
+	circles := make([]circumTriangle, len(triangles))
+	for i, triangle := range triangles {
+		circles[i] = newCircumTriangle(triangle)
+	}
+	return circles, randomPoints(2, 64)
+}
+
+func BenchmarkCircumcircleContains5k(b *testing.B) {
+	circles, probes := benchmarkCircles(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := probes[i%len(probes)]
+		for _, circle := range circles {
+			circle.triangle.CircumcircleContains(p)
+		}
+	}
+}
+
+func BenchmarkCachedCircumcircle5k(b *testing.B) {
+	circles, probes := benchmarkCircles(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := probes[i%len(probes)]
+		for _, circle := range circles {
+			circle.contains(p, 0)
+		}
+	}
+}
+
+func BenchmarkDelaunayTriangulation5k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 5000, DelaunayTriangulation)
+}
+
+func BenchmarkListTriangulate5k(b *testing.B) {
+	benchmarkDelaunayTriangulation(b, 5000, listTriangulate)
+}
+
+func TestArea(t *testing.T) {
+	tests := []struct {
+		triangle Triangle
+		signed   float64
+	}{
+		{Triangle{Point{0, 0}, Point{1, 0}, Point{0, 1}}, 0.5},
+		{Triangle{Point{0, 0}, Point{0, 1}, Point{1, 0}}, -0.5},
+		{Triangle{Point{-2, -1}, Point{4, -1}, Point{1, 7}}, 24},
+		{Triangle{Point{1e6, 1e6}, Point{1e6 + 3, 1e6}, Point{1e6, 1e6 + 4}}, 6},
+		{Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}}, 0},
+	}
+	for _, test := range tests {
+		if got := test.triangle.SignedArea(); got != test.signed {
+			t.Errorf("%v.SignedArea() = %v, want %v", test.triangle, got, test.signed)
+		}
+		if got := test.triangle.Area(); got != math.Abs(test.signed) {
+			t.Errorf("%v.Area() = %v, want %v", test.triangle, got, math.Abs(test.signed))
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	counter_clockwise := Triangle{Point{0, 0}, Point{4, 0}, Point{0, 4}}
+	clockwise := Triangle{Point{0, 0}, Point{0, 4}, Point{4, 0}}
+	tests := []struct {
+		name string
+		p    Point
+		want bool
+	}{
+		{"inside", Point{1, 1}, true},
+		{"outside", Point{3, 3}, false},
+		{"outside past a vertex", Point{-1, 0}, false},
+		{"on an edge", Point{2, 2}, true},
+		{"on an axis edge", Point{2, 0}, true},
+		{"on a vertex", Point{4, 0}, true},
+		{"just outside an edge", Point{2, 2 + 1e-12}, false},
+	}
+	for _, test := range tests {
+		for _, triangle := range []Triangle{counter_clockwise, clockwise} {
+			if got := triangle.Contains(test.p); got != test.want {
+				t.Errorf("%s: %v.Contains(%v) = %v, want %v", test.name, triangle, test.p, got, test.want)
+			}
+		}
+	}
+
+	degenerate := Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}}
+	if degenerate.Contains(Point{1, 1}) {
+		t.Errorf("degenerate %v contains a point", degenerate)
+	}
+}
+
+func TestEdgeFields(t *testing.T) {
+	a, b := Point{1, 2}, Point{3, 4}
+	edge := Edge{A: a, B: b}
+	if edge.A != a || edge.B != b {
+		t.Errorf("Edge{A: %v, B: %v} has endpoints %v and %v", a, b, edge.A, edge.B)
+	}
+	if !edge.isEqual(Edge{A: b, B: a}) || edge.isEqual(Edge{A: a, B: Point{3, 5}}) {
+		t.Errorf("%v compares wrongly", edge)
+	}
+}
+
+func TestPointHelpers(t *testing.T) {
+	p, q := Point{4, 6}, Point{1, 2}
+	if got := p.Distance(q); got != 5 {
+		t.Errorf("%v.Distance(%v) = %v, want 5", p, q, got)
+	}
+	if got := p.DistanceSq(q); got != 25 {
+		t.Errorf("%v.DistanceSq(%v) = %v, want 25", p, q, got)
+	}
+	if got := q.Distance(p); got != 5 {
+		t.Errorf("%v.Distance(%v) = %v, want 5", q, p, got)
+	}
+	if got := p.Distance(p); got != 0 {
+		t.Errorf("%v.Distance(%v) = %v, want 0", p, p, got)
+	}
+	if got := p.Sub(q); got != (Point{3, 4}) {
+		t.Errorf("%v.Sub(%v) = %v, want (3, 4)", p, q, got)
+	}
+	if got := p.Add(q); got != (Point{5, 8}) {
+		t.Errorf("%v.Add(%v) = %v, want (5, 8)", p, q, got)
+	}
+	if got := p.Dot(q); got != 16 {
+		t.Errorf("%v.Dot(%v) = %v, want 16", p, q, got)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		points   []Point
+		min, max Point
+	}{
+		{nil, Point{}, Point{}},
+		{[]Point{{3, -4}}, Point{3, -4}, Point{3, -4}},
+		{[]Point{{1, 2}, {3, 4}}, Point{1, 2}, Point{3, 4}},
+		{[]Point{{-1, 5}, {-7, -2}, {4, -3}, {0, 0}}, Point{-7, -3}, Point{4, 5}},
+		{[]Point{{-5, -5}, {-2, -9}}, Point{-5, -9}, Point{-2, -5}},
+	}
+	for _, test := range tests {
+		if min, max := BoundingBox(test.points); min != test.min || max != test.max {
+			t.Errorf("BoundingBox(%v) = %v, %v, want %v, %v", test.points, min, max, test.min, test.max)
+		}
+	}
+}
+
+func TestEdgeLengthAndMidpoint(t *testing.T) {
+	tests := []struct {
+		edge     Edge
+		length   float64
+		midpoint Point
+	}{
+		{Edge{Point{0, 0}, Point{3, 0}}, 3, Point{1.5, 0}},
+		{Edge{Point{3, 0}, Point{0, 0}}, 3, Point{1.5, 0}},
+		{Edge{Point{-1, -1}, Point{2, 3}}, 5, Point{0.5, 1}},
+		{Edge{Point{2, 2}, Point{2, 2}}, 0, Point{2, 2}},
+	}
+	for _, test := range tests {
+		if got := test.edge.Length(); got != test.length {
+			t.Errorf("%v.Length() = %v, want %v", test.edge, got, test.length)
+		}
+		if got := test.edge.Midpoint(); got != test.midpoint {
+			t.Errorf("%v.Midpoint() = %v, want %v", test.edge, got, test.midpoint)
+		}
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		triangle Triangle
+		want     Point
+	}{
+		{Triangle{Point{0, 0}, Point{3, 0}, Point{0, 3}}, Point{1, 1}},
+		{Triangle{Point{-3, -6}, Point{3, 0}, Point{6, 9}}, Point{2, 1}},
+		{Triangle{Point{1, 1}, Point{1, 1}, Point{1, 1}}, Point{1, 1}},
+	}
+	for _, test := range tests {
+		if got := test.triangle.Centroid(); got != test.want {
+			t.Errorf("%v.Centroid() = %v, want %v", test.triangle, got, test.want)
+		}
+		if test.triangle.Area() > 0 && !test.triangle.Contains(test.triangle.Centroid()) {
+			t.Errorf("%v does not contain its centroid", test.triangle)
+		}
+	}
+}
+
+func TestTriangleEqual(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{1, 0}, Point{0, 1}, Point{1, 1}
+	triangle := Triangle{a, b, c}
+	tests := []struct {
+		other Triangle
+		equal bool
+	}{
+		{Triangle{a, b, c}, true},
+		{Triangle{c, a, b}, true},
+		{Triangle{b, c, a}, true},
+		{Triangle{b, a, c}, true},
+		{Triangle{a, b, d}, false},
+		{Triangle{a, b, b}, false},
+		{Triangle{d, d, d}, false},
+	}
+	for _, test := range tests {
+		if got := triangle.Equal(test.other); got != test.equal {
+			t.Errorf("%v.Equal(%v) = %v, want %v", triangle, test.other, got, test.equal)
+		}
+		if got := test.other.Equal(triangle); got != test.equal {
+			t.Errorf("%v.Equal(%v) = %v, want %v", test.other, triangle, got, test.equal)
+		}
+	}
+}
+
+func TestIncircle(t *testing.T) {
+	equilateral := Triangle{Point{0, 0}, Point{2, 0}, Point{1, math.Sqrt(3)}}
+	tests := []struct {
+		triangle Triangle
+		center   Point
+		radius   float64
+	}{
+		{equilateral, equilateral.Centroid(), 1 / math.Sqrt(3)},
+		{Triangle{Point{0, 0}, Point{4, 0}, Point{0, 3}}, Point{1, 1}, 1},
+		{Triangle{Point{5, 5}, Point{5, 2}, Point{9, 2}}, Point{6, 3}, 1},
+		{Triangle{Point{0, 0}, Point{1, 0}, Point{3, 0}}, Point{1, 0}, 0},
+		{Triangle{Point{2, 3}, Point{2, 3}, Point{2, 3}}, Point{2, 3}, 0},
+	}
+	for _, test := range tests {
+		center, radius := test.triangle.Incircle()
+		if !nearlyEqual(center, test.center) || math.Abs(radius-test.radius) > 1e-12 {
+			t.Errorf("%v.Incircle() = %v, %v, want %v, %v", test.triangle, center, radius, test.center, test.radius)
+		}
+	}
+}
+
+func TestRemoveSuperTriangle(t *testing.T) {
+	points := randomPoints(113, 300)
+	super := ComputeSuperTriangle(points)
+	triangulation := NewTriangulation(super)
+	for _, p := range points {
+		triangulation.Insert(p)
+	}
+	all := triangulation.AllTriangles()
+
+	kept := RemoveSuperTriangle(append([]Triangle(nil), all...), super)
+	touches := func(triangle Triangle) bool {
+		return triangle.ContainsPoint(super.A) || triangle.ContainsPoint(super.B) || triangle.ContainsPoint(super.C)
+	}
+	var want []Triangle
+	for _, triangle := range all {
+		if !touches(triangle) {
+			want = append(want, triangle)
+		}
+	}
+	if len(want) == len(all) {
+		t.Fatal("no triangle touches the super triangle")
+	}
+	if !reflect.DeepEqual(kept, want) {
+		t.Errorf("kept %d of %d triangles, want the %d that do not touch the super triangle", len(kept), len(all), len(want))
+	}
+	if got := triangulation.Triangles(); !reflect.DeepEqual(got, kept) {
+		t.Errorf("Triangles() gave %d triangles, RemoveSuperTriangle %d", len(got), len(kept))
+	}
+	if got := DelaunayTriangulation(points, super); len(got) != len(kept) {
+		t.Errorf("DelaunayTriangulation gave %d triangles, RemoveSuperTriangle %d", len(got), len(kept))
+	} else if edge, ok := matchesReference(kept, true, edgeSet(got)); !ok {
+		t.Errorf("DelaunayTriangulation and RemoveSuperTriangle differ at %v", edge)
+	}
+
+	// Only the vertices count, a triangle inside the super triangle is kept
+	inner := Triangle{Point{0, 0}, Point{1, 0}, Point{0, 1}}
+	tests := []struct {
+		triangle Triangle
+		kept     bool
+	}{
+		{inner, true},
+		{Triangle{super.A, inner.A, inner.B}, false},
+		{Triangle{inner.A, super.B, inner.C}, false},
+		{Triangle{inner.B, inner.C, super.C}, false},
+		{super, false},
+	}
+	for _, test := range tests {
+		got := RemoveSuperTriangle([]Triangle{test.triangle}, super)
+		if (len(got) == 1) != test.kept {
+			t.Errorf("RemoveSuperTriangle(%v) = %v, want kept %v", test.triangle, got, test.kept)
+		}
+	}
+}
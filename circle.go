@@ -0,0 +1,19 @@
+package bowyer_watson
+
+// A circle given by its center and radius
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Given an array of triangles, return the circumcircle of each
+// A degenerate triangle has no circumcircle, so its entry has a NaN center and an
+// infinite radius, see Circumcenter and CircumRadius
+// Return: One circle per triangle, in the same order as triangles
+func Circumcircles(triangles []Triangle) []Circle {
+	circles := make([]Circle, len(triangles))
+	for i, triangle := range triangles {
+		circles[i] = Circle{triangle.Circumcenter(), triangle.CircumRadius()}
+	}
+	return circles
+}
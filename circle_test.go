@@ -0,0 +1,30 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircumcirclesPassThroughVertices(t *testing.T) {
+	triangles, err := Triangulate(randomPoints(41, 200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	triangles = append(triangles, Triangle{Point{1e6, 1e6}, Point{1e6 + 3, 1e6}, Point{1e6, 1e6 + 4}})
+	circles := Circumcircles(triangles)
+	if len(circles) != len(triangles) {
+		t.Fatalf("Circumcircles() gave %d circles for %d triangles", len(circles), len(triangles))
+	}
+	for i, circle := range circles {
+		for _, p := range []Point{triangles[i].A, triangles[i].B, triangles[i].C} {
+			if d := p.Distance(circle.Center); math.Abs(d-circle.Radius) > 1e-9*circle.Radius {
+				t.Errorf("%v is %v from the center of %v, radius %v", p, d, triangles[i], circle.Radius)
+			}
+		}
+	}
+
+	degenerate := Circumcircles([]Triangle{{Point{0, 0}, Point{1, 1}, Point{2, 2}}})[0]
+	if !math.IsNaN(degenerate.Center.X) || !math.IsInf(degenerate.Radius, 1) {
+		t.Errorf("degenerate triangle has circle %v, want a NaN center and infinite radius", degenerate)
+	}
+}
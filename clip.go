@@ -0,0 +1,32 @@
+package bowyer_watson
+
+// Given an array of triangles, return the parts of them inside the rectangle
+// between min and max
+// Triangles entirely inside the rectangle are kept as they are and triangles
+// entirely outside it are dropped. A triangle crossing the boundary is cut down to
+// the convex polygon it shares with the rectangle, which is split into a fan of
+// triangles from its first corner, so one triangle may become up to five. Pieces
+// with no area, such as a triangle that only touches the rectangle, are dropped.
+// Return: The clipped triangles, in the order of the triangles they came from
+func ClipToRect(triangles []Triangle, min, max Point) []Triangle {
+	inside := func(p Point) bool {
+		return p.X >= min.X && p.X <= max.X && p.Y >= min.Y && p.Y <= max.Y
+	}
+
+	var clipped []Triangle
+	for _, triangle := range triangles {
+		if inside(triangle.A) && inside(triangle.B) && inside(triangle.C) {
+			clipped = append(clipped, triangle)
+			continue
+		}
+
+		polygon := clipPolygonToRect([]Point{triangle.A, triangle.B, triangle.C}, min, max)
+		for i := 2; i < len(polygon); i++ {
+			piece := Triangle{polygon[0], polygon[i-1], polygon[i]}
+			if Orient2D(piece.A, piece.B, piece.C) != 0 {
+				clipped = append(clipped, piece)
+			}
+		}
+	}
+	return clipped
+}
@@ -0,0 +1,51 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClipToRect(t *testing.T) {
+	min, max := Point{0, 0}, Point{2, 2}
+	inside := Triangle{Point{0.5, 0.5}, Point{1.5, 0.5}, Point{0.5, 1.5}}
+	outside := Triangle{Point{3, 3}, Point{4, 3}, Point{3, 4}}
+	touching := Triangle{Point{2, 0}, Point{3, 0}, Point{2, 1}}
+	// Crosses the right side of the rectangle, which cuts a quarter of it off
+	straddling := Triangle{Point{1, 0.5}, Point{3, 0.5}, Point{1, 1.5}}
+
+	clipped := ClipToRect([]Triangle{inside, outside, touching}, min, max)
+	if len(clipped) != 1 || clipped[0] != inside {
+		t.Errorf("ClipToRect() = %v, want only %v", clipped, inside)
+	}
+
+	clipped = ClipToRect([]Triangle{straddling}, min, max)
+	if len(clipped) != 2 {
+		t.Fatalf("ClipToRect(%v) = %v, want the quadrilateral as 2 triangles", straddling, clipped)
+	}
+	for _, piece := range clipped {
+		for _, p := range []Point{piece.A, piece.B, piece.C} {
+			if p.X < min.X || p.X > max.X || p.Y < min.Y || p.Y > max.Y {
+				t.Errorf("ClipToRect(%v) has %v outside the rectangle", straddling, p)
+			}
+			if Orient2D(straddling.A, straddling.B, p) < 0 || Orient2D(straddling.B, straddling.C, p) < 0 ||
+				Orient2D(straddling.C, straddling.A, p) < 0 {
+				t.Errorf("ClipToRect(%v) has %v outside the triangle", straddling, p)
+			}
+		}
+	}
+	if area := TotalArea(clipped); math.Abs(area-0.75) > 1e-12 {
+		t.Errorf("ClipToRect(%v) covers %v, want 0.75", straddling, area)
+	}
+
+	// A triangulation reaching past the rectangle on every side is cut to fill it
+	points := []Point{{-1, -1}, {3, -1}, {3, 3}, {-1, 3}}
+	points = append(points, randomPoints(29, 100)...)
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	min, max = Point{0.25, 0.5}, Point{2, 1}
+	if area := TotalArea(ClipToRect(triangles, min, max)); math.Abs(area-0.875) > 1e-12 {
+		t.Errorf("the clipped triangulation covers %v, want 0.875", area)
+	}
+}
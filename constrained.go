@@ -0,0 +1,122 @@
+package bowyer_watson
+
+import (
+	"fmt"
+)
+
+// Given an array of points and edges between them, return a triangulation in
+// which every one of those edges appears
+// The points are triangulated as usual, then each constraint edge missing from
+// the result is forced in by flipping the edges that cross it, after which the
+// new edges are flipped back towards the Delaunay condition. The result is
+// therefore Delaunay except where a constraint prevents it: a triangle beside a
+// constraint may have another point in its circumcircle, on the far side of the
+// constraint.
+// Return: The triangles, or an error if the points cannot be triangulated, a
+// constraint does not join two of the points, two constraints cross, or a
+// constraint passes through another point
+func ConstrainedTriangulation(points []Point, constraints []Edge) ([]Triangle, error) {
+	triangles, err := Triangulate(points)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newFlipMesh(triangles)
+	constrained := make(map[Edge]bool, len(constraints))
+	for _, constraint := range constraints {
+		if err := m.insertConstraint(constraint, constrained); err != nil {
+			return nil, err
+		}
+		constrained[constraint.normalized()] = true
+	}
+
+	return m.triangles, nil
+}
+
+// flipMesh method
+// Flips edges until the constraint edge appears in the mesh, never flipping any
+// edge in constrained
+// Return: An error if the constraint cannot be inserted
+func (m *flipMesh) insertConstraint(constraint Edge, constrained map[Edge]bool) error {
+	if constraint.A == constraint.B {
+		return fmt.Errorf("bowyer_watson: constraint %v has no length", constraint)
+	}
+
+	vertices := make(map[Point]bool)
+	for _, triangle := range m.triangles {
+		vertices[triangle.A], vertices[triangle.B], vertices[triangle.C] = true, true, true
+	}
+	if !vertices[constraint.A] || !vertices[constraint.B] {
+		return fmt.Errorf("bowyer_watson: constraint %v does not join two of the points", constraint)
+	}
+	if _, ok := m.edges[constraint.normalized()]; ok {
+		return nil
+	}
+
+	for v := range vertices {
+		if v != constraint.A && v != constraint.B && onSegment(constraint, v) {
+			return fmt.Errorf("bowyer_watson: constraint %v passes through point %v", constraint, v)
+		}
+	}
+
+	var crossing []Edge
+	for edge := range m.edges {
+		if !crosses(edge, constraint) {
+			continue
+		}
+		if constrained[edge] {
+			return fmt.Errorf("bowyer_watson: constraint %v crosses constraint %v", constraint, edge)
+		}
+		crossing = append(crossing, edge)
+	}
+
+	// Each crossing edge is flipped once its quadrilateral is convex; until then
+	// it goes to the back of the queue. A flip that still crosses the constraint
+	// is queued again, and one that does not is kept for the Delaunay pass.
+	var created []Edge
+	limit := 10 * (len(crossing) + 1) * (len(crossing) + 1)
+	for attempts := 0; len(crossing) > 0; attempts++ {
+		if attempts > limit {
+			return fmt.Errorf("bowyer_watson: could not insert constraint %v", constraint)
+		}
+
+		edge := crossing[0]
+		crossing = crossing[1:]
+		if !m.canFlip(edge) {
+			crossing = append(crossing, edge)
+			continue
+		}
+
+		flipped := m.flip(edge)
+		if crosses(flipped, constraint) {
+			crossing = append(crossing, flipped)
+		} else {
+			created = append(created, flipped)
+		}
+	}
+
+	m.legalize(created, func(e Edge) bool {
+		return constrained[e.normalized()] || e.normalized() == constraint.normalized()
+	})
+
+	if _, ok := m.edges[constraint.normalized()]; !ok {
+		return fmt.Errorf("bowyer_watson: could not insert constraint %v", constraint)
+	}
+	return nil
+}
+
+// Given two edges, determine if they cross at a point inside both of them
+// Edges that only touch, or share an endpoint, do not cross
+func crosses(e1, e2 Edge) bool {
+	return Orient2D(e1.A, e1.B, e2.A)*Orient2D(e1.A, e1.B, e2.B) < 0 &&
+		Orient2D(e2.A, e2.B, e1.A)*Orient2D(e2.A, e2.B, e1.B) < 0
+}
+
+// Given an edge and a point, determine if the point lies on the edge, including
+// its endpoints
+func onSegment(e Edge, p Point) bool {
+	if Orient2D(e.A, e.B, p) != 0 {
+		return false
+	}
+	return (p.X-e.A.X)*(p.X-e.B.X) <= 0 && (p.Y-e.A.Y)*(p.Y-e.B.Y) <= 0
+}
@@ -0,0 +1,72 @@
+package bowyer_watson
+
+import "testing"
+
+// Return: The edges joining each corner of the polygon to the next
+func polygonEdges(polygon []Point) []Edge {
+	edges := make([]Edge, len(polygon))
+	for i, p := range polygon {
+		edges[i] = Edge{p, polygon[(i+1)%len(polygon)]}
+	}
+	return edges
+}
+
+func TestConstrainedTriangulationPolygon(t *testing.T) {
+	// A "C" shape, whose inner corners are close enough to cut across with a
+	// Delaunay edge
+	polygon := []Point{{0, 0}, {10, 0}, {10, 2}, {3, 2.2}, {3, 7.8}, {10, 8}, {10, 10}, {0, 10}}
+	points := append(append([]Point(nil), polygon...), randomPoints(2, 30)...)
+	for i := len(polygon); i < len(points); i++ {
+		points[i] = Point{points[i].X * 10, points[i].Y * 10}
+	}
+	constraints := polygonEdges(polygon)
+
+	plain := edgeSet(mustTriangulate(t, points))
+	missing := 0
+	for _, constraint := range constraints {
+		if !plain[constraint.normalized()] {
+			missing++
+		}
+	}
+	if missing == 0 {
+		t.Fatal("the unconstrained triangulation already has every edge of the polygon")
+	}
+
+	triangles, err := ConstrainedTriangulation(points, constraints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edges := edgeSet(triangles)
+	for _, constraint := range constraints {
+		if !edges[constraint.normalized()] {
+			t.Errorf("constraint %v is missing", constraint)
+		}
+	}
+	for edge := range edges {
+		for _, constraint := range constraints {
+			if crosses(edge, constraint) {
+				t.Errorf("%v crosses constraint %v", edge, constraint)
+			}
+		}
+	}
+	if err := checkHullArea(triangles, points); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConstrainedTriangulationErrors(t *testing.T) {
+	points := []Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 1}}
+	tests := []struct {
+		name        string
+		constraints []Edge
+	}{
+		{"not between points", []Edge{{Point{0, 0}, Point{3, 3}}}},
+		{"crossing", []Edge{{Point{0, 0}, Point{2, 2}}, {Point{2, 0}, Point{0, 2}}}},
+		{"through a point", []Edge{{Point{0, 0}, Point{2, 2}}}},
+	}
+	for _, test := range tests {
+		if _, err := ConstrainedTriangulation(points, test.constraints); err == nil {
+			t.Errorf("%s: ConstrainedTriangulation(%v) succeeded", test.name, test.constraints)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package bowyer_watson
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Given a reader of CSV rows of the form x,y, return the points they describe
+// The first row may be a header, which is skipped when its fields are not numbers.
+// Return: The points, or an error naming the line of the first malformed row
+func ReadPointsCSV(r io.Reader) ([]Point, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var points []Point
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return points, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bowyer_watson: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != 2 {
+			return nil, fmt.Errorf("bowyer_watson: line %d: expected 2 fields, got %d", line, len(record))
+		}
+
+		x, err_x := strconv.ParseFloat(record[0], 64)
+		y, err_y := strconv.ParseFloat(record[1], 64)
+		if err_x != nil || err_y != nil {
+			if row == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("bowyer_watson: line %d: %q,%q is not a pair of numbers", line, record[0], record[1])
+		}
+		points = append(points, Point{x, y})
+	}
+}
+
+// Given an array of triangles, write them as CSV
+// Each triangle is one row of six fields, A.X, A.Y, B.X, B.Y, C.X, C.Y, written
+// with enough digits to be read back exactly
+// Return: The first error from writing to w
+func WriteTrianglesCSV(w io.Writer, triangles []Triangle) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, 6)
+	for _, t := range triangles {
+		for k, p := range [3]Point{t.A, t.B, t.C} {
+			record[2*k] = strconv.FormatFloat(p.X, 'g', -1, 64)
+			record[2*k+1] = strconv.FormatFloat(p.Y, 'g', -1, 64)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
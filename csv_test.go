@@ -0,0 +1,67 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadPointsCSV(t *testing.T) {
+	input := "x,y\n0,0\n1.5, -2\n1e3,0.1\n"
+	points, err := ReadPointsCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Point{{0, 0}, {1.5, -2}, {1e3, 0.1}}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("ReadPointsCSV() = %v, want %v", points, want)
+	}
+
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"0,0\n1,a\n", `bowyer_watson: line 2: "1","a" is not a pair of numbers`},
+		{"x,y\n0,0\n1,2,3\n", "bowyer_watson: line 3: expected 2 fields, got 3"},
+	}
+	for _, test := range tests {
+		if _, err := ReadPointsCSV(strings.NewReader(test.input)); err == nil || err.Error() != test.message {
+			t.Errorf("ReadPointsCSV(%q) error = %v, want %q", test.input, err, test.message)
+		}
+	}
+}
+
+func TestTrianglesCSVRoundTrip(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{1, 0}, Point{0, 1}},
+		{Point{0.1, 1.0 / 3}, Point{-1e300, math.Pi}, Point{math.SmallestNonzeroFloat64, 7}},
+	}
+	var buffer bytes.Buffer
+	if err := WriteTrianglesCSV(&buffer, triangles); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(triangles) {
+		t.Fatalf("got %d rows, want %d", len(records), len(triangles))
+	}
+	for i, record := range records {
+		var coordinates [6]float64
+		for k, field := range record {
+			if coordinates[k], err = strconv.ParseFloat(field, 64); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got := Triangle{Point{coordinates[0], coordinates[1]}, Point{coordinates[2], coordinates[3]}, Point{coordinates[4], coordinates[5]}}
+		if got != triangles[i] {
+			t.Errorf("row %d reads back as %v, want %v", i, got, triangles[i])
+		}
+	}
+}
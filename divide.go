@@ -0,0 +1,289 @@
+package bowyer_watson
+
+import "sort"
+
+// Given an array of points, return an array of triangles of the triangulation
+// This is the divide and conquer algorithm of Guibas and Stolfi: the points are
+// sorted, each half is triangulated recursively, and the two halves are stitched
+// together along their common boundary. It takes O(n log n) time, against the
+// roughly O(n^2) of DelaunayTriangulation. The triangles are the same as those of
+// Triangulate, except that where four or more points are cocircular either
+// triangulation of them may be chosen.
+// Return: The triangles, or an error describing why the points were rejected
+func TriangulateDivideConquer(points []Point) ([]Triangle, error) {
+	var d divideConquer
+	return d.triangulate(points, nil)
+}
+
+// The memory used by TriangulateDivideConquer, which a Triangulator keeps between
+// calls
+type divideConquer struct {
+	// The sorted points, the distinct ones moved to the front
+	sorted []Point
+	edges  quadEdges
+	faces  [][3]int
+}
+
+// divideConquer method
+// Triangulates the points as TriangulateDivideConquer does, reusing the memory of
+// the previous call
+// Return: The triangles appended to triangles, or an error describing why the
+// points were rejected
+func (d *divideConquer) triangulate(points []Point, triangles []Triangle) ([]Triangle, error) {
+	if err := ValidatePoints(points); err != nil {
+		return nil, err
+	}
+
+	d.sorted = append(d.sorted[:0], points...)
+	sort.Slice(d.sorted, func(i, j int) bool { return pointLess(d.sorted[i], d.sorted[j]) })
+	distinct := d.sorted[:1]
+	for _, p := range d.sorted[1:] {
+		if p != distinct[len(distinct)-1] {
+			distinct = append(distinct, p)
+		}
+	}
+
+	// The distinct points are a prefix of sorted, so the predicates can index it
+	m := &d.edges
+	if m.orient == nil {
+		m.orient = func(a, b, c int) int {
+			return sign(Orient2D(d.sorted[a], d.sorted[b], d.sorted[c]))
+		}
+		m.incircle = func(a, b, c, e int) int {
+			return sign(IncircleDeterminant(d.sorted[a], d.sorted[b], d.sorted[c], d.sorted[e]))
+		}
+	}
+	m.reset()
+	m.delaunay(0, len(distinct))
+
+	d.faces = m.appendTriangles(d.faces[:0])
+	for _, face := range d.faces {
+		triangles = append(triangles, Triangle{distinct[face[0]], distinct[face[1]], distinct[face[2]]})
+	}
+	return triangles, nil
+}
+
+// Given a value, return its sign: -1, 0 or 1
+func sign(x float64) int {
+	if x > 0 {
+		return 1
+	}
+	if x < 0 {
+		return -1
+	}
+	return 0
+}
+
+// The quad-edge structure of Guibas and Stolfi
+// Each undirected edge is a group of four directed edges: the edge, its dual
+// (rotated a quarter turn), its reverse and its reversed dual. A directed edge is
+// referred to by 4 times its group plus its rotation.
+// Points are referred to by their index in a sorted array of distinct points,
+// and are only examined through the two predicates, which return the sign of
+// Orient2D and IncircleDeterminant for the points at the given indices.
+type quadEdges struct {
+	// The next edge counter-clockwise around the origin of each directed edge
+	next []int
+	// The index of the origin of each directed edge. Only meaningful for the
+	// edge and its reverse, not the duals
+	origin  []int
+	deleted []bool
+
+	orient   func(a, b, c int) int
+	incircle func(a, b, c, d int) int
+
+	// Scratch space for appendTriangles
+	visited []bool
+}
+
+// quadEdges method
+// Removes every edge, keeping the memory and the predicates
+func (m *quadEdges) reset() {
+	m.next = m.next[:0]
+	m.origin = m.origin[:0]
+	m.deleted = m.deleted[:0]
+}
+
+func rot(e int) int    { return e&^3 | (e+1)&3 }
+func sym(e int) int    { return e&^3 | (e+2)&3 }
+func rotInv(e int) int { return e&^3 | (e+3)&3 }
+
+func (m *quadEdges) onext(e int) int { return m.next[e] }
+func (m *quadEdges) oprev(e int) int { return rot(m.next[rot(e)]) }
+func (m *quadEdges) lnext(e int) int { return rot(m.next[rotInv(e)]) }
+func (m *quadEdges) rprev(e int) int { return m.next[sym(e)] }
+
+func (m *quadEdges) org(e int) int  { return m.origin[e] }
+func (m *quadEdges) dest(e int) int { return m.origin[sym(e)] }
+
+// quadEdges method
+// Adds an edge from point a to point b that is not connected to any other
+// Return: The new edge
+func (m *quadEdges) makeEdge(a, b int) int {
+	e := len(m.next)
+	m.next = append(m.next, e, e+3, e+2, e+1)
+	m.origin = append(m.origin, a, 0, b, 0)
+	m.deleted = append(m.deleted, false)
+	return e
+}
+
+// quadEdges method
+// Joins the rings of edges around the origins of a and b if they are separate,
+// or splits them if they are the same
+func (m *quadEdges) splice(a, b int) {
+	alpha, beta := rot(m.next[a]), rot(m.next[b])
+	m.next[a], m.next[b] = m.next[b], m.next[a]
+	m.next[alpha], m.next[beta] = m.next[beta], m.next[alpha]
+}
+
+// quadEdges method
+// Adds an edge from the destination of a to the origin of b, so that a, the new
+// edge and b all have the same face on their left
+// Return: The new edge
+func (m *quadEdges) connect(a, b int) int {
+	e := m.makeEdge(m.dest(a), m.org(b))
+	m.splice(e, m.lnext(a))
+	m.splice(sym(e), b)
+	return e
+}
+
+// quadEdges method
+// Disconnects an edge from the rest of the structure
+func (m *quadEdges) deleteEdge(e int) {
+	m.splice(e, m.oprev(e))
+	m.splice(sym(e), m.oprev(sym(e)))
+	m.deleted[e/4] = true
+}
+
+// quadEdges method
+// Return: True if point p is strictly to the right of e
+func (m *quadEdges) rightOf(p int, e int) bool {
+	return m.orient(p, m.dest(e), m.org(e)) > 0
+}
+
+// quadEdges method
+// Return: True if point p is strictly to the left of e
+func (m *quadEdges) leftOf(p int, e int) bool {
+	return m.orient(p, m.org(e), m.dest(e)) > 0
+}
+
+// quadEdges method
+// Triangulates the points with indices lo to hi-1
+// Return: The counter-clockwise convex hull edge out of the leftmost point, and
+// the clockwise convex hull edge out of the rightmost point
+func (m *quadEdges) delaunay(lo, hi int) (int, int) {
+	switch hi - lo {
+	case 2:
+		a := m.makeEdge(lo, lo+1)
+		return a, sym(a)
+	case 3:
+		a := m.makeEdge(lo, lo+1)
+		b := m.makeEdge(lo+1, lo+2)
+		m.splice(sym(a), b)
+
+		orientation := m.orient(lo, lo+1, lo+2)
+		if orientation > 0 {
+			m.connect(b, a)
+			return a, sym(b)
+		}
+		if orientation < 0 {
+			c := m.connect(b, a)
+			return sym(c), c
+		}
+		return a, sym(b)
+	}
+
+	mid := (lo + hi) / 2
+	ldo, ldi := m.delaunay(lo, mid)
+	rdi, rdo := m.delaunay(mid, hi)
+
+	// Find the lower common tangent of the two halves
+	for {
+		if m.leftOf(m.org(rdi), ldi) {
+			ldi = m.lnext(ldi)
+		} else if m.rightOf(m.org(ldi), rdi) {
+			rdi = m.rprev(rdi)
+		} else {
+			break
+		}
+	}
+
+	basel := m.connect(sym(rdi), ldi)
+	if m.org(ldi) == m.org(ldo) {
+		ldo = sym(basel)
+	}
+	if m.org(rdi) == m.org(rdo) {
+		rdo = basel
+	}
+
+	// Zip the halves together from the bottom up, each step adding a cross edge
+	// from whichever candidate's circle is empty
+	valid := func(e int) bool { return m.rightOf(m.dest(e), basel) }
+	for {
+		lcand := m.onext(sym(basel))
+		if valid(lcand) {
+			for m.incircle(m.dest(basel), m.org(basel), m.dest(lcand), m.dest(m.onext(lcand))) > 0 {
+				t := m.onext(lcand)
+				m.deleteEdge(lcand)
+				lcand = t
+			}
+		}
+
+		rcand := m.oprev(basel)
+		if valid(rcand) {
+			for m.incircle(m.dest(basel), m.org(basel), m.dest(rcand), m.dest(m.oprev(rcand))) > 0 {
+				t := m.oprev(rcand)
+				m.deleteEdge(rcand)
+				rcand = t
+			}
+		}
+
+		if !valid(lcand) && !valid(rcand) {
+			break
+		}
+		if !valid(lcand) || valid(rcand) && m.incircle(m.dest(lcand), m.org(lcand), m.org(rcand), m.dest(rcand)) > 0 {
+			basel = m.connect(rcand, sym(basel))
+		} else {
+			basel = m.connect(sym(basel), sym(lcand))
+		}
+	}
+
+	return ldo, rdo
+}
+
+// quadEdges method
+// Return: The point indices of every counter-clockwise face with three edges
+func (m *quadEdges) triangles() [][3]int {
+	return m.appendTriangles(nil)
+}
+
+// quadEdges method
+// As triangles, reusing the memory of the previous call
+// Return: The point indices of every counter-clockwise face with three edges,
+// appended to triangles
+func (m *quadEdges) appendTriangles(triangles [][3]int) [][3]int {
+	if cap(m.visited) < len(m.next) {
+		m.visited = make([]bool, len(m.next))
+	}
+	visited := m.visited[:len(m.next)]
+	for i := range visited {
+		visited[i] = false
+	}
+	for e := 0; e < len(m.next); e += 2 {
+		if m.deleted[e/4] || visited[e] {
+			continue
+		}
+
+		a, b := e, m.lnext(e)
+		c := m.lnext(b)
+		if m.lnext(c) != a {
+			continue
+		}
+		visited[a], visited[b], visited[c] = true, true, true
+
+		if m.orient(m.org(a), m.org(b), m.org(c)) > 0 {
+			triangles = append(triangles, [3]int{m.org(a), m.org(b), m.org(c)})
+		}
+	}
+	return triangles
+}
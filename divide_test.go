@@ -0,0 +1,55 @@
+package bowyer_watson
+
+import "testing"
+
+func TestDivideConquerMatchesTriangulate(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		points := randomPoints(seed, 1500)
+		got, err := TriangulateDivideConquer(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkCoversHull(got, points); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		want, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("seed %d: got %d triangles, want %d", seed, len(got), len(want))
+		}
+		if edge, ok := matchesReference(got, true, edgeSet(want)); !ok {
+			t.Fatalf("seed %d: the triangulations differ at %v", seed, edge)
+		}
+	}
+
+	// Cocircular points may be triangulated either way, so only the shape is checked
+	points := latticePoints(30)
+	triangles, err := TriangulateDivideConquer(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCoversHull(triangles, points); err != nil || len(triangles) != 2*29*29 {
+		t.Errorf("lattice: got %d triangles, %v, want %d", len(triangles), err, 2*29*29)
+	}
+}
+
+func benchmarkTriangulate(b *testing.B, n int, triangulate func([]Point) ([]Triangle, error)) {
+	points := randomPoints(1, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := triangulate(points); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTriangulate50k(b *testing.B) {
+	benchmarkTriangulate(b, 50000, Triangulate)
+}
+
+func BenchmarkTriangulateDivideConquer50k(b *testing.B) {
+	benchmarkTriangulate(b, 50000, TriangulateDivideConquer)
+}
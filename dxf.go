@@ -0,0 +1,23 @@
+package bowyer_watson
+
+import (
+	"io"
+)
+
+// Given an array of triangles, write them as an AutoCAD DXF drawing
+// The drawing is the minimal form that DXF readers accept, a single ENTITIES
+// section, with each triangle as a 3DFACE on layer 0 with a Z of 0. A 3DFACE has
+// four corners, so the triangle's last corner is repeated as the format requires.
+// Return: The first error from writing to w
+func WriteDXF(w io.Writer, triangles []Triangle) error {
+	ew := &errWriter{w: w}
+	ew.printf("0\nSECTION\n2\nENTITIES\n")
+	for _, t := range triangles {
+		ew.printf("0\n3DFACE\n8\n0\n")
+		for k, p := range [4]Point{t.A, t.B, t.C, t.C} {
+			ew.printf("1%d\n%g\n2%d\n%g\n3%d\n0\n", k, p.X, k, p.Y, k)
+		}
+	}
+	ew.printf("0\nENDSEC\n0\nEOF\n")
+	return ew.err
+}
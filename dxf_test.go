@@ -0,0 +1,96 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Given a DXF drawing, read the corners of its 3DFACE entities as a minimal DXF
+// reader would: as pairs of lines, a group code and its value
+// Return: The corners of each face, and the number of entities of any kind
+func readDXFFaces(t *testing.T, data []byte) ([][4]Point, int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var faces [][4]Point
+	entities := 0
+	in_face, in_entities, ended := false, false, false
+	for scanner.Scan() {
+		code, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			t.Fatalf("bad group code %q", scanner.Text())
+		}
+		if !scanner.Scan() {
+			t.Fatalf("group code %d has no value", code)
+		}
+		value := strings.TrimSpace(scanner.Text())
+		if ended {
+			t.Fatalf("group %d %q after EOF", code, value)
+		}
+
+		switch {
+		case code == 0:
+			in_face = false
+			switch value {
+			case "SECTION", "ENDSEC":
+				in_entities = false
+			case "EOF":
+				ended = true
+			case "3DFACE":
+				if !in_entities {
+					t.Fatal("3DFACE outside the ENTITIES section")
+				}
+				in_face = true
+				faces = append(faces, [4]Point{})
+				fallthrough
+			default:
+				entities++
+			}
+		case code == 2 && value == "ENTITIES":
+			in_entities = true
+		case in_face && code >= 10 && code <= 33:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatalf("group %d: %v", code, err)
+			}
+			corner := &faces[len(faces)-1][code%10]
+			switch code / 10 {
+			case 1:
+				corner.X = number
+			case 2:
+				corner.Y = number
+			case 3:
+				if number != 0 {
+					t.Errorf("corner has Z %v, want 0", number)
+				}
+			}
+		}
+	}
+	if !ended {
+		t.Fatal("the drawing does not end with EOF")
+	}
+	return faces, entities
+}
+
+func TestWriteDXF(t *testing.T) {
+	triangles, err := Triangulate(randomPoints(37, 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	if err := WriteDXF(&buffer, triangles); err != nil {
+		t.Fatal(err)
+	}
+
+	faces, entities := readDXFFaces(t, buffer.Bytes())
+	if entities != len(triangles) || len(faces) != len(triangles) {
+		t.Fatalf("got %d entities and %d faces, want %d", entities, len(faces), len(triangles))
+	}
+	for i, face := range faces {
+		triangle := triangles[i]
+		if want := [4]Point{triangle.A, triangle.B, triangle.C, triangle.C}; face != want {
+			t.Errorf("face %d has corners %v, want %v", i, face, want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package bowyer_watson
+
+// Given the triangles of a triangulation, flip edges until it is Delaunay
+// Any two triangles sharing an edge whose circumcircle holds the vertex across it
+// have their shared diagonal flipped (Lawson's algorithm), which can repair a
+// triangulation made some other way or damaged by round-off. Flipped triangles
+// are counter-clockwise.
+// Return: The Delaunay triangles, in a new slice with one triangle per input triangle
+func EnforceDelaunay(triangles []Triangle) []Triangle {
+	m := newFlipMesh(triangles)
+	m.legalize(Edges(triangles), nil)
+	return m.triangles
+}
+
+// Given the triangles of a triangulation, flip edges to shorten the total edge
+// length
+// Whenever the diagonal of two triangles forming a convex quadrilateral is longer
+// than the other diagonal it is flipped, which shortens the total by the
+// difference, until no flip helps. This gives a local optimum, an approximation of
+// the minimum weight triangulation, rather than the best triangulation overall.
+// Every flip strictly shortens the total, so the process ends, and the number of
+// flips is also capped as for EnforceDelaunay.
+// Return: The triangles, in a new slice with one triangle per input triangle
+func MinimizeEdgeLength(triangles []Triangle) []Triangle {
+	m := newFlipMesh(triangles)
+	pending := Edges(triangles)
+
+	limit := 10 * (len(m.triangles) + 1) * (len(m.triangles) + 1)
+	for flips := 0; len(pending) > 0 && flips < limit; {
+		e := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		_, _, c, d, ok := m.across(e)
+		if !ok || c.DistanceSq(d) >= e.A.DistanceSq(e.B) || !m.canFlip(e) {
+			continue
+		}
+
+		m.flip(e)
+		flips++
+		pending = append(pending, Edge{e.A, c}, Edge{c, e.B}, Edge{e.B, d}, Edge{d, e.A})
+	}
+	return m.triangles
+}
+
+// Triangle method
+// Flips the edge shared with another triangle: the two triangles form a
+// quadrilateral, and it is split along its other diagonal instead, as Lawson's
+// algorithm does. Flipping the results again gives back the original pair.
+// Return: The two new triangles, wound the same way as t, with the first holding
+// the vertex of the shared edge that comes first in t, and true; or false if the
+// triangles do not share exactly one edge or the quadrilateral is not strictly
+// convex, so that a flipped triangle would be degenerate or overlap the other
+func (t Triangle) Flip(other Triangle) (Triangle, Triangle, bool) {
+	e, ok := t.SharedEdge(other)
+	if !ok {
+		return Triangle{}, Triangle{}, false
+	}
+	a, b := e.A, e.B
+	c, d := t.opposite(e), other.opposite(e)
+	if Orient2D(c, d, a)*Orient2D(c, d, b) >= 0 || Orient2D(a, b, c)*Orient2D(a, b, d) >= 0 {
+		return Triangle{}, Triangle{}, false
+	}
+
+	// t is a, b, c in some rotation, so c, a, d and c, d, b turn the same way
+	return Triangle{c, a, d}, Triangle{c, d, b}, true
+}
+
+// A triangulation stored so that the diagonal shared by two triangles can be
+// flipped cheaply. Each edge maps to the (one or two) triangles that use it.
+type flipMesh struct {
+	triangles []Triangle
+	edges     map[Edge][]int
+}
+
+// Given the triangles of a triangulation, index them by edge
+// The triangles are copied, so the caller's slice is left untouched
+func newFlipMesh(triangles []Triangle) *flipMesh {
+	m := &flipMesh{
+		triangles: append([]Triangle(nil), triangles...),
+		edges:     make(map[Edge][]int, 3*len(triangles)),
+	}
+	for i, triangle := range m.triangles {
+		for _, edge := range triangle.edges() {
+			key := edge.normalized()
+			m.edges[key] = append(m.edges[key], i)
+		}
+	}
+	return m
+}
+
+// flipMesh method
+// Finds the two triangles on either side of an edge
+// Return: Their indices, the vertex of each opposite the edge, and false if the
+// edge is not shared by exactly two triangles
+func (m *flipMesh) across(e Edge) (i, j int, c, d Point, ok bool) {
+	sides := m.edges[e.normalized()]
+	if len(sides) != 2 {
+		return 0, 0, Point{}, Point{}, false
+	}
+	i, j = sides[0], sides[1]
+	return i, j, m.triangles[i].opposite(e), m.triangles[j].opposite(e), true
+}
+
+// flipMesh method
+// Determines if an edge can be flipped, which needs the quadrilateral formed by
+// its two triangles to be strictly convex
+// Return: True if the edge can be flipped
+func (m *flipMesh) canFlip(e Edge) bool {
+	_, _, c, d, ok := m.across(e)
+	if !ok {
+		return false
+	}
+	return Orient2D(c, d, e.A)*Orient2D(c, d, e.B) < 0
+}
+
+// flipMesh method
+// Replaces an edge AB, shared by triangles ABC and BAD, with the edge CD
+// The caller must check canFlip first
+// Return: The new edge
+func (m *flipMesh) flip(e Edge) Edge {
+	i, j, c, d, _ := m.across(e)
+	a, b := e.A, e.B
+
+	m.triangles[i] = Triangle{c, a, d}.ToCCW()
+	m.triangles[j] = Triangle{c, d, b}.ToCCW()
+
+	delete(m.edges, e.normalized())
+	m.replace(Edge{a, d}, j, i)
+	m.replace(Edge{c, b}, i, j)
+	m.edges[Edge{c, d}.normalized()] = []int{i, j}
+
+	return Edge{c, d}
+}
+
+// flipMesh method
+// Changes which triangle an edge refers to after a flip
+func (m *flipMesh) replace(e Edge, old_index, new_index int) {
+	for k, index := range m.edges[e.normalized()] {
+		if index == old_index {
+			m.edges[e.normalized()][k] = new_index
+			return
+		}
+	}
+}
+
+// flipMesh method
+// Determines if an edge satisfies the empty-circumcircle condition, meaning the
+// vertex across the edge is not strictly inside the circumcircle of either triangle
+// Return: True for boundary edges and edges that are locally Delaunay
+func (m *flipMesh) locallyDelaunay(e Edge) bool {
+	i, _, _, d, ok := m.across(e)
+	if !ok {
+		return true
+	}
+
+	var in = IncircleDeterminant(m.triangles[i].A, m.triangles[i].B, m.triangles[i].C, d)
+	if m.triangles[i].Orientation() == CW {
+		in = -in
+	}
+	return in <= 0
+}
+
+// flipMesh method
+// Flips edges that are not locally Delaunay until none remain, starting from the
+// given edges and following the edges each flip disturbs. Edges for which fixed
+// returns true are never flipped.
+// The number of flips is capped so that round-off cannot cause an endless loop
+func (m *flipMesh) legalize(pending []Edge, fixed func(Edge) bool) {
+	limit := 10 * (len(m.triangles) + 1) * (len(m.triangles) + 1)
+	for flips := 0; len(pending) > 0 && flips < limit; {
+		e := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if fixed != nil && fixed(e) || m.locallyDelaunay(e) || !m.canFlip(e) {
+			continue
+		}
+
+		flipped := m.flip(e)
+		flips++
+		c, d := flipped.A, flipped.B
+		pending = append(pending, Edge{e.A, c}, Edge{c, e.B}, Edge{e.B, d}, Edge{d, e.A})
+	}
+}
+
+// Triangle method
+// Given one of the triangle's edges, find the vertex not on it
+// Return: The vertex opposite the edge
+func (t Triangle) opposite(e Edge) Point {
+	for _, p := range [3]Point{t.A, t.B, t.C} {
+		if p != e.A && p != e.B {
+			return p
+		}
+	}
+	return t.C
+}
@@ -0,0 +1,141 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEnforceDelaunayQuad(t *testing.T) {
+	// The long diagonal a-c of this kite is not Delaunay, as d is inside the
+	// circumcircle of a, b, c
+	a, b, c, d := Point{0, 0}, Point{2, -1}, Point{4, 0}, Point{2, 1}
+	got := EnforceDelaunay([]Triangle{{a, b, c}, {a, c, d}})
+	if len(got) != 2 {
+		t.Fatalf("EnforceDelaunay() = %v, want 2 triangles", got)
+	}
+	edges := edgeSet(got)
+	if edges[Edge{a, c}.normalized()] || !edges[Edge{b, d}.normalized()] {
+		t.Errorf("EnforceDelaunay() = %v, want the diagonal %v-%v", got, b, d)
+	}
+	if err := Validate(got); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEnforceDelaunayRepairs(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	points := randomPoints(21, 200)
+	triangles := mustTriangulate(t, points)
+
+	// Flip a third of the edges at random to spoil the triangulation
+	mesh := newFlipMesh(triangles)
+	for _, edge := range Edges(triangles) {
+		if r.Intn(3) == 0 && mesh.canFlip(edge) {
+			mesh.flip(edge)
+		}
+	}
+	if Validate(mesh.triangles) == nil {
+		t.Fatal("the flipped triangulation is still Delaunay")
+	}
+
+	repaired := EnforceDelaunay(mesh.triangles)
+	if err := checkCoversHull(repaired, points); err != nil {
+		t.Error(err)
+	}
+	if edge, ok := matchesReference(repaired, true, edgeSet(triangles)); !ok {
+		t.Errorf("the repaired triangulation differs at %v", edge)
+	}
+}
+
+func TestMinimizeEdgeLength(t *testing.T) {
+	// The diagonal b-d of this kite is much shorter than a-c
+	a, b, c, d := Point{0, 0}, Point{2, -0.5}, Point{5, 0}, Point{2, 0.5}
+	got := MinimizeEdgeLength([]Triangle{{a, b, c}, {a, c, d}})
+	if edges := edgeSet(got); len(got) != 2 || edges[Edge{a, c}.normalized()] || !edges[Edge{b, d}.normalized()] {
+		t.Errorf("MinimizeEdgeLength() = %v, want the diagonal %v-%v", got, b, d)
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		points := randomPoints(seed, 150)
+		triangles := mustTriangulate(t, points)
+		before := totalLength(Edges(triangles))
+
+		shortened := MinimizeEdgeLength(triangles)
+		after := totalLength(Edges(shortened))
+		if after > before {
+			t.Errorf("seed %d: total length went from %v up to %v", seed, before, after)
+		}
+		if len(shortened) != len(triangles) {
+			t.Errorf("seed %d: got %d triangles, want %d", seed, len(shortened), len(triangles))
+		}
+		if err := checkHullArea(shortened, points); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+
+		// At a local optimum no flip shortens an edge, so another pass changes nothing
+		again := MinimizeEdgeLength(shortened)
+		if edge, ok := matchesReference(again, true, edgeSet(shortened)); !ok {
+			t.Errorf("seed %d: a second pass changed %v", seed, edge)
+		}
+		mesh := newFlipMesh(shortened)
+		for _, edge := range Edges(shortened) {
+			_, _, c, d, ok := mesh.across(edge)
+			if ok && mesh.canFlip(edge) && c.DistanceSq(d) < edge.A.DistanceSq(edge.B) {
+				t.Errorf("seed %d: flipping %v would shorten it", seed, edge)
+			}
+		}
+	}
+}
+
+func TestTriangleFlip(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{2, 2}, Point{0, 2}
+	tests := []struct {
+		name         string
+		first, other Triangle
+	}{
+		{"counter-clockwise", Triangle{a, b, c}, Triangle{a, c, d}},
+		{"clockwise", Triangle{a, c, b}, Triangle{a, d, c}},
+		{"kite", Triangle{Point{0, 0}, Point{2, -1}, Point{5, 0}}, Triangle{Point{0, 0}, Point{5, 0}, Point{2, 1}}},
+	}
+	for _, test := range tests {
+		p, q, ok := test.first.Flip(test.other)
+		if !ok {
+			t.Fatalf("%s: Flip() failed", test.name)
+		}
+		shared, _ := test.first.SharedEdge(test.other)
+		flipped, found := p.SharedEdge(q)
+		if !found || flipped.isEqual(shared) || !p.ContainsPoint(shared.A) || !q.ContainsPoint(shared.B) {
+			t.Errorf("%s: Flip() = %v, %v, want them split along the other diagonal to %v", test.name, p, q, shared)
+		}
+		if p.Orientation() != test.first.Orientation() || q.Orientation() != test.first.Orientation() {
+			t.Errorf("%s: Flip() = %v, %v, not wound as %v", test.name, p, q, test.first)
+		}
+		if area, want := p.Area()+q.Area(), test.first.Area()+test.other.Area(); math.Abs(area-want) > 1e-12 {
+			t.Errorf("%s: the flipped pair covers %v, want %v", test.name, area, want)
+		}
+
+		// Flipping again gives back the original pair
+		r, s, ok := p.Flip(q)
+		if !ok || !(r.Equal(test.first) && s.Equal(test.other) || r.Equal(test.other) && s.Equal(test.first)) {
+			t.Errorf("%s: Flip(Flip()) = %v, %v, %v, want %v and %v", test.name, r, s, ok, test.first, test.other)
+		}
+	}
+
+	failures := []struct {
+		name         string
+		first, other Triangle
+	}{
+		{"disjoint", Triangle{a, b, c}, Triangle{Point{5, 5}, Point{6, 5}, Point{5, 6}}},
+		{"one vertex", Triangle{a, b, c}, Triangle{c, Point{3, 2}, Point{3, 3}}},
+		{"same", Triangle{a, b, c}, Triangle{c, a, b}},
+		{"not convex", Triangle{a, b, Point{1, 0.5}}, Triangle{a, Point{1, 0.5}, Point{2, 2}}},
+		{"flat corner", Triangle{a, b, c}, Triangle{a, c, Point{2, 4}}},
+		{"degenerate diagonal", Triangle{a, b, Point{1, 1}}, Triangle{a, Point{1, 1}, c}},
+	}
+	for _, test := range failures {
+		if p, q, ok := test.first.Flip(test.other); ok {
+			t.Errorf("%s: Flip() = %v, %v, want false", test.name, p, q)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Point method
+// Formats the point for logs and error messages, with each coordinate in the
+// shortest form that reads back as the same float64
+// Return: The point as (X, Y), for example (1.5, 2)
+func (p Point) String() string {
+	return "(" + formatCoordinate(p.X) + ", " + formatCoordinate(p.Y) + ")"
+}
+
+// Edge method
+// Return: The edge as [A-B], for example [(0, 0)-(1, 1)]
+func (e Edge) String() string {
+	return "[" + e.A.String() + "-" + e.B.String() + "]"
+}
+
+// Triangle method
+// Return: The triangle as △ followed by A, B and C, for example △(0, 0)(1, 0)(0, 1)
+func (t Triangle) String() string {
+	return "△" + t.A.String() + t.B.String() + t.C.String()
+}
+
+// WeightedPoint method
+// Without this the String method of the embedded Point would leave out the weight
+// Return: The point followed by its weight, for example (1, 2) w=0.5
+func (p WeightedPoint) String() string {
+	return p.Point.String() + " w=" + formatCoordinate(p.Weight)
+}
+
+// LabeledPoint method
+// Without this the String method of the embedded Point would leave out the data
+// Return: The point followed by its data as formatted by %v, for example (1, 2) a
+func (p LabeledPoint[T]) String() string {
+	return p.Point.String() + " " + fmt.Sprint(p.Data)
+}
+
+// Given a coordinate, return it in the shortest form that parses back to it
+func formatCoordinate(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
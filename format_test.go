@@ -0,0 +1,38 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestStringGolden(t *testing.T) {
+	tests := []struct {
+		value fmt.Stringer
+		want  string
+	}{
+		{Point{1.5, 2}, "(1.5, 2)"},
+		{Point{-0.1, 1e21}, "(-0.1, 1e+21)"},
+		{Point{math.NaN(), math.Inf(-1)}, "(NaN, -Inf)"},
+		{Point{1.0 / 3, 0}, "(0.3333333333333333, 0)"},
+		{Edge{Point{0, 0}, Point{1, 1}}, "[(0, 0)-(1, 1)]"},
+		{Triangle{Point{0, 0}, Point{1, 0}, Point{0, 1}}, "△(0, 0)(1, 0)(0, 1)"},
+		{WeightedPoint{Point{1, 2}, 0.5}, "(1, 2) w=0.5"},
+		{LabeledPoint[string]{Point{1, 2}, "a"}, "(1, 2) a"},
+		{LabeledPoint[int]{Point{3, 4}, 7}, "(3, 4) 7"},
+	}
+	for _, test := range tests {
+		if got := test.value.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+		if got := fmt.Sprintf("%v", test.value); got != test.want {
+			t.Errorf("%%v = %q, want %q", got, test.want)
+		}
+	}
+
+	// Slices of them, as in test failures, use the same forms
+	edges := []Edge{{Point{0, 0}, Point{1, 0}}, {Point{1, 0}, Point{2, 2}}}
+	if got, want := fmt.Sprint(edges), "[[(0, 0)-(1, 0)] [(1, 0)-(2, 2)]]"; got != want {
+		t.Errorf("fmt.Sprint(%#v) = %q, want %q", edges, got, want)
+	}
+}
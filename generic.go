@@ -0,0 +1,65 @@
+package bowyer_watson
+
+// The coordinate types the generic triangulation accepts
+type Floating interface {
+	~float32 | ~float64
+}
+
+// Basic x,y coordinate with a caller-chosen floating point type
+// PointOf[float64] holds the same data as Point
+type PointOf[F Floating] struct {
+	X, Y F
+}
+
+// Triangle with a caller-chosen floating point type, see Triangle
+type TriangleOf[F Floating] struct {
+	A, B, C PointOf[F]
+}
+
+// Edge with a caller-chosen floating point type, see Edge
+type EdgeOf[F Floating] struct {
+	A, B PointOf[F]
+}
+
+// PointOf method
+// Return: The point as a float64 Point
+func (p PointOf[F]) Float64() Point {
+	return Point{float64(p.X), float64(p.Y)}
+}
+
+// TriangleOf method
+// Return: The triangle as a float64 Triangle
+func (t TriangleOf[F]) Float64() Triangle {
+	return Triangle{t.A.Float64(), t.B.Float64(), t.C.Float64()}
+}
+
+// EdgeOf method
+// Return: The edge as a float64 Edge
+func (e EdgeOf[F]) Float64() Edge {
+	return Edge{e.A.Float64(), e.B.Float64()}
+}
+
+// Given an array of points, return an array of triangles of the triangulation
+// This is DelaunayTriangulation for any Floating coordinate type. The geometry is
+// evaluated in float64, which is exact for float32 input, and every output vertex
+// is one of the input points, so no precision is lost converting back.
+// Super triangle is a triangle that contains all the points, see ComputeSuperTriangle
+func DelaunayTriangulationOf[F Floating](points []PointOf[F], super_triangle TriangleOf[F]) []TriangleOf[F] {
+	converted := make([]Point, len(points))
+	original := make(map[Point]PointOf[F], len(points)+3)
+	for i, p := range points {
+		converted[i] = p.Float64()
+		original[converted[i]] = p
+	}
+	for _, p := range [3]PointOf[F]{super_triangle.A, super_triangle.B, super_triangle.C} {
+		original[p.Float64()] = p
+	}
+
+	triangles := DelaunayTriangulation(converted, super_triangle.Float64())
+
+	result := make([]TriangleOf[F], len(triangles))
+	for i, t := range triangles {
+		result[i] = TriangleOf[F]{original[t.A], original[t.B], original[t.C]}
+	}
+	return result
+}
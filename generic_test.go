@@ -0,0 +1,47 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// A named coordinate type, which Floating accepts as well as float32 itself
+type meters float32
+
+func TestDelaunayTriangulationOfFloat32(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	points := make([]PointOf[float32], 100)
+	converted := make([]Point, len(points))
+	for i := range points {
+		points[i] = PointOf[float32]{r.Float32() * 100, r.Float32() * 100}
+		converted[i] = points[i].Float64()
+	}
+	super := ComputeSuperTriangle(converted)
+	super32 := TriangleOf[float32]{
+		PointOf[float32]{float32(super.A.X), float32(super.A.Y)},
+		PointOf[float32]{float32(super.B.X), float32(super.B.Y)},
+		PointOf[float32]{float32(super.C.X), float32(super.C.Y)},
+	}
+
+	got := DelaunayTriangulationOf(points, super32)
+	want := DelaunayTriangulation(converted, super32.Float64())
+	if len(got) != len(want) || len(got) == 0 {
+		t.Fatalf("got %d float32 triangles, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].Float64() != want[i] {
+			t.Errorf("triangle %d is %v, want %v", i, got[i].Float64(), want[i])
+		}
+	}
+
+	named := []PointOf[meters]{{0, 0}, {1, 0}, {0, 1}, {1, 1.5}}
+	super_named := TriangleOf[meters]{PointOf[meters]{-100, -100}, PointOf[meters]{0, 100}, PointOf[meters]{100, -100}}
+	triangles := DelaunayTriangulationOf(named, super_named)
+	converted_triangles := make([]Triangle, len(triangles))
+	for i, triangle := range triangles {
+		converted_triangles[i] = triangle.Float64()
+	}
+	if err := Validate(converted_triangles); err != nil || len(triangles) != 2 {
+		t.Errorf("got %v, want 2 valid triangles: %v", triangles, err)
+	}
+}
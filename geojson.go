@@ -0,0 +1,60 @@
+package bowyer_watson
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type geoJSONGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// Given an array of triangles, write them as a GeoJSON FeatureCollection
+// Each triangle becomes a Polygon feature whose ring is closed by repeating its
+// first vertex, wound counter-clockwise as RFC 7946 asks of exterior rings.
+// Positions are written as [X, Y], so for geographic data X must hold the
+// longitude and Y the latitude.
+// properties, if not nil, is called with each triangle and its index to supply
+// the feature's properties; otherwise every feature has null properties
+// Return: The first error from encoding or writing to w
+func WriteGeoJSON(w io.Writer, triangles []Triangle, properties func(i int, t Triangle) map[string]interface{}) error {
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]geoJSONFeature, len(triangles)),
+	}
+
+	for i, t := range triangles {
+		ring := t.ToCCW()
+
+		feature := geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type: "Polygon",
+				Coordinates: [][][2]float64{{
+					{ring.A.X, ring.A.Y},
+					{ring.B.X, ring.B.Y},
+					{ring.C.X, ring.C.Y},
+					{ring.A.X, ring.A.Y},
+				}},
+			},
+		}
+		if properties != nil {
+			feature.Properties = properties(i, t)
+		}
+		collection.Features[i] = feature
+	}
+
+	return json.NewEncoder(w).Encode(collection)
+}
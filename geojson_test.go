@@ -0,0 +1,56 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteGeoJSON(t *testing.T) {
+	triangles := []Triangle{
+		{Point{-73.99, 40.73}, Point{-73.98, 40.75}, Point{-74.00, 40.74}},
+		{Point{-73.99, 40.73}, Point{-74.00, 40.74}, Point{-73.98, 40.75}},
+		{Point{-73.98, 40.75}, Point{-73.97, 40.72}, Point{-73.99, 40.73}},
+	}
+	var buffer bytes.Buffer
+	err := WriteGeoJSON(&buffer, triangles, func(i int, triangle Triangle) map[string]interface{} {
+		return map[string]interface{}{"index": i}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Type     string
+		Features []struct {
+			Type     string
+			Geometry struct {
+				Type        string
+				Coordinates [][][2]float64
+			}
+			Properties map[string]float64
+		}
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &document); err != nil {
+		t.Fatalf("WriteGeoJSON wrote invalid JSON: %v", err)
+	}
+	if document.Type != "FeatureCollection" || len(document.Features) != len(triangles) {
+		t.Fatalf("got a %s of %d features, want a FeatureCollection of %d", document.Type, len(document.Features), len(triangles))
+	}
+	for i, feature := range document.Features {
+		if feature.Type != "Feature" || feature.Geometry.Type != "Polygon" || feature.Properties["index"] != float64(i) {
+			t.Errorf("feature %d is %+v", i, feature)
+			continue
+		}
+		ring := feature.Geometry.Coordinates[0]
+		if len(ring) != 4 || ring[0] != ring[3] {
+			t.Errorf("feature %d has ring %v, want 4 positions closing on the first", i, ring)
+			continue
+		}
+		// The ring is the triangle, counter-clockwise, with X first
+		polygon := Triangle{Point{ring[0][0], ring[0][1]}, Point{ring[1][0], ring[1][1]}, Point{ring[2][0], ring[2][1]}}
+		if !polygon.Equal(triangles[i]) || polygon.SignedArea() <= 0 {
+			t.Errorf("feature %d has ring %v for %v", i, ring, triangles[i])
+		}
+	}
+}
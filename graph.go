@@ -0,0 +1,192 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Given an array of points, return the edges of their Euclidean minimum spanning tree
+// The minimum spanning tree is a subgraph of the Delaunay triangulation, so only
+// the triangulation's edges are considered, shortest first (Kruskal's algorithm).
+// Collinear points cannot be triangulated; they are instead joined in order along
+// their line, which is their minimum spanning tree.
+// Return: The tree's edges, or nil for fewer than two distinct points or a point
+// that is not finite
+func MinimumSpanningTree(points []Point) []Edge {
+	triangles, chain := triangulateOrChain(points)
+	if triangles == nil {
+		return chain
+	}
+
+	edges := Edges(triangles)
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].A.DistanceSq(edges[i].B) < edges[j].A.DistanceSq(edges[j].B)
+	})
+
+	components := newDisjointSet()
+	var tree []Edge
+	for _, edge := range edges {
+		if components.union(edge.A, edge.B) {
+			tree = append(tree, edge)
+		}
+	}
+	return tree
+}
+
+// Given an array of points, return the edges of their Gabriel graph
+// An edge is in the Gabriel graph when no other point lies inside the circle that
+// has the edge as its diameter. Points exactly on that circle do not exclude the
+// edge. Every such edge is a Delaunay edge, and it is enough to test the vertices
+// opposite the edge in the triangles on either side of it.
+// Return: The edges, or for collinear points the edges joining neighbors along the
+// line, or nil if a point is not finite
+func GabrielGraph(points []Point) []Edge {
+	triangles, chain := triangulateOrChain(points)
+	if triangles == nil {
+		return chain
+	}
+
+	excluded := make(map[Edge]bool, 3*len(triangles))
+	for _, triangle := range triangles {
+		for k, edge := range triangle.edges() {
+			opposite := [3]Point{triangle.C, triangle.A, triangle.B}[k]
+			if insideDiametralCircle(edge, opposite) {
+				excluded[edge.normalized()] = true
+			}
+		}
+	}
+
+	var graph []Edge
+	for _, edge := range Edges(triangles) {
+		if !excluded[edge.normalized()] {
+			graph = append(graph, edge)
+		}
+	}
+	return graph
+}
+
+// Given an edge and a point, determine if the point is strictly inside the circle
+// with the edge as its diameter, which is when the edge subtends an obtuse angle
+// at the point
+func insideDiametralCircle(e Edge, p Point) bool {
+	return (e.A.X-p.X)*(e.B.X-p.X)+(e.A.Y-p.Y)*(e.B.Y-p.Y) < 0
+}
+
+// Given an array of points, return the edges of their relative neighborhood graph
+// An edge from p to q is kept when no third point r is closer to both p and q than
+// they are to each other, that is when the lune between them is empty. Points on
+// the edge of the lune do not exclude the edge. Every such edge is a Delaunay edge.
+// Return: The edges, or for collinear points the edges joining neighbors along the
+// line, or nil if a point is not finite
+func RelativeNeighborhoodGraph(points []Point) []Edge {
+	triangles, chain := triangulateOrChain(points)
+	if triangles == nil {
+		return chain
+	}
+
+	// The lune of an edge lies within its length of either endpoint, so sorting
+	// by X limits the search to a window of points
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].X < sorted[j].X
+	})
+
+	var graph []Edge
+	for _, edge := range Edges(triangles) {
+		length_sq := edge.A.DistanceSq(edge.B)
+		length := math.Sqrt(length_sq)
+
+		first := sort.Search(len(sorted), func(i int) bool {
+			return sorted[i].X >= edge.A.X-length
+		})
+
+		empty := true
+		for _, r := range sorted[first:] {
+			if r.X > edge.A.X+length {
+				break
+			}
+			if edge.A.DistanceSq(r) < length_sq && edge.B.DistanceSq(r) < length_sq {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			graph = append(graph, edge)
+		}
+	}
+	return graph
+}
+
+// Given an array of points, triangulate them for one of the graphs made from the
+// Delaunay edges
+// Collinear points, and fewer than three, cannot be triangulated, and each of
+// those graphs is then the chain joining neighbors along the line. A point that is
+// not finite gives neither.
+// Return: The triangles, or nil and the chain, which is nil too if a point is not
+// finite
+func triangulateOrChain(points []Point) ([]Triangle, []Edge) {
+	triangles, err := Triangulate(points)
+	if errors.Is(err, ErrCollinearPoints) || errors.Is(err, ErrTooFewPoints) {
+		return nil, collinearChain(points)
+	}
+	return triangles, nil
+}
+
+// Given an array of collinear points, return the edges joining neighboring points
+// along their line
+func collinearChain(points []Point) []Edge {
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return pointLess(sorted[i], sorted[j])
+	})
+
+	var chain []Edge
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] != sorted[i-1] {
+			chain = append(chain, Edge{sorted[i-1], sorted[i]})
+		}
+	}
+	return chain
+}
+
+// Union-find over points, used to track which points are already connected
+type disjointSet struct {
+	parent map[Point]Point
+}
+
+func newDisjointSet() *disjointSet {
+	return &disjointSet{make(map[Point]Point)}
+}
+
+// disjointSet method
+// Return: The representative point of the set containing p
+func (s *disjointSet) find(p Point) Point {
+	root := p
+	for {
+		parent, ok := s.parent[root]
+		if !ok || parent == root {
+			break
+		}
+		root = parent
+	}
+	// Point everything on the path straight at the root
+	for p != root {
+		next := s.parent[p]
+		s.parent[p] = root
+		p = next
+	}
+	return root
+}
+
+// disjointSet method
+// Merges the sets containing p and q
+// Return: False if they were already in the same set
+func (s *disjointSet) union(p, q Point) bool {
+	root_p, root_q := s.find(p), s.find(q)
+	if root_p == root_q {
+		return false
+	}
+	s.parent[root_p] = root_q
+	return true
+}
@@ -0,0 +1,166 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+// Return: The total length of the edges
+func totalLength(edges []Edge) float64 {
+	var total float64
+	for _, edge := range edges {
+		total += edge.Length()
+	}
+	return total
+}
+
+// Return: The weight of the minimum spanning tree of the complete graph on the
+// points, found with Prim's algorithm over every pair
+func bruteMinimumSpanningTree(points []Point) float64 {
+	in_tree := make([]bool, len(points))
+	distance := make([]float64, len(points))
+	for i := range distance {
+		distance[i] = math.Inf(1)
+	}
+	distance[0] = 0
+
+	var total float64
+	for range points {
+		next := -1
+		for i := range points {
+			if !in_tree[i] && (next < 0 || distance[i] < distance[next]) {
+				next = i
+			}
+		}
+		in_tree[next] = true
+		total += distance[next]
+		for i, p := range points {
+			if d := p.Distance(points[next]); !in_tree[i] && d < distance[i] {
+				distance[i] = d
+			}
+		}
+	}
+	return total
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		points := randomPoints(seed, 60)
+		tree := MinimumSpanningTree(points)
+		if len(tree) != len(points)-1 {
+			t.Fatalf("seed %d: got %d edges, want %d", seed, len(tree), len(points)-1)
+		}
+		if got, want := totalLength(tree), bruteMinimumSpanningTree(points); math.Abs(got-want) > 1e-12 {
+			t.Errorf("seed %d: tree weighs %v, want %v", seed, got, want)
+		}
+	}
+
+	collinear := []Point{{3, 3}, {0, 0}, {1, 1}, {1, 1}}
+	tree := MinimumSpanningTree(collinear)
+	if len(tree) != 2 || math.Abs(totalLength(tree)-3*math.Sqrt2) > 1e-12 {
+		t.Errorf("MinimumSpanningTree(%v) = %v", collinear, tree)
+	}
+
+	for _, bad := range [][]Point{{{1, 0}, {math.NaN(), 0}, {0, 1}}, {{1, 0}, {math.Inf(-1), 0}}} {
+		if tree := MinimumSpanningTree(bad); tree != nil {
+			t.Errorf("MinimumSpanningTree(%v) = %v, want nil", bad, tree)
+		}
+	}
+}
+
+// Determines if the edges are exactly the wanted edges, in any order and direction
+func sameEdgeList(got []Edge, want ...Edge) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	wanted := make(map[Edge]bool, len(want))
+	for _, edge := range want {
+		wanted[edge.normalized()] = true
+	}
+	for _, edge := range got {
+		if !wanted[edge.normalized()] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGabrielGraph(t *testing.T) {
+	// c is inside the circle with a-b as its diameter, though a-b is a Delaunay
+	// edge, as d is far below it
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{1, 0.5}, Point{1, -3}
+	points := []Point{a, b, c, d}
+	if !edgeSet(mustTriangulate(t, points))[Edge{a, b}.normalized()] {
+		t.Fatalf("%v-%v is not a Delaunay edge", a, b)
+	}
+	graph := GabrielGraph(points)
+	if !sameEdgeList(graph, Edge{a, c}, Edge{b, c}, Edge{a, d}, Edge{b, d}) {
+		t.Errorf("GabrielGraph(%v) = %v, want every Delaunay edge but %v-%v", points, graph, a, b)
+	}
+
+	// A point exactly on the diametral circle does not exclude the edge
+	on_circle := []Point{{0, 0}, {2, 0}, {1, 1}, {1, -3}}
+	if graph := GabrielGraph(on_circle); len(graph) != 5 {
+		t.Errorf("GabrielGraph(%v) = %v, want all 5 Delaunay edges", on_circle, graph)
+	}
+
+	if line := []Point{{2, 0}, {0, 0}, {1, 0}}; !sameEdgeList(GabrielGraph(line), Edge{Point{0, 0}, Point{1, 0}}, Edge{Point{1, 0}, Point{2, 0}}) {
+		t.Errorf("GabrielGraph(%v) = %v, want the chain along the line", line, GabrielGraph(line))
+	}
+	if bad := []Point{{1, 0}, {math.Inf(1), 0}}; GabrielGraph(bad) != nil {
+		t.Errorf("GabrielGraph(%v) = %v, want nil", bad, GabrielGraph(bad))
+	}
+}
+
+// Return: The triangles of the points, failing the test if they cannot be triangulated
+func mustTriangulate(t *testing.T, points []Point) []Triangle {
+	t.Helper()
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return triangles
+}
+
+func TestRelativeNeighborhoodGraph(t *testing.T) {
+	// c is closer to both a and b than they are to each other, but outside the
+	// circle with a-b as its diameter, so a-b is a Gabriel edge but not an RNG one
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{1, 1.2}, Point{1, -3}
+	points := []Point{a, b, c, d}
+	if !sameEdgeList(GabrielGraph(points), Edge{a, b}, Edge{a, c}, Edge{b, c}, Edge{a, d}, Edge{b, d}) {
+		t.Fatalf("GabrielGraph(%v) = %v, want all 5 Delaunay edges", points, GabrielGraph(points))
+	}
+	graph := RelativeNeighborhoodGraph(points)
+	if !sameEdgeList(graph, Edge{a, c}, Edge{b, c}, Edge{a, d}, Edge{b, d}) {
+		t.Errorf("RelativeNeighborhoodGraph(%v) = %v, want every Delaunay edge but %v-%v", points, graph, a, b)
+	}
+	if bad := []Point{{0, 0}, {1, 0}, {0, math.NaN()}, {1, 1}}; RelativeNeighborhoodGraph(bad) != nil {
+		t.Errorf("RelativeNeighborhoodGraph(%v) = %v, want nil", bad, RelativeNeighborhoodGraph(bad))
+	}
+
+	// The RNG always contains the minimum spanning tree and is within the Gabriel graph
+	for seed := int64(0); seed < 10; seed++ {
+		points := randomPoints(seed, 80)
+		rng := edgeSetOf(RelativeNeighborhoodGraph(points))
+		gabriel := edgeSetOf(GabrielGraph(points))
+		for edge := range rng {
+			if !gabriel[edge] {
+				t.Errorf("seed %d: RNG edge %v is not a Gabriel edge", seed, edge)
+			}
+		}
+		for _, edge := range MinimumSpanningTree(points) {
+			if !rng[edge.normalized()] {
+				t.Errorf("seed %d: spanning tree edge %v is not an RNG edge", seed, edge)
+			}
+		}
+	}
+}
+
+// Return: The set of the normalized edges
+func edgeSetOf(edges []Edge) map[Edge]bool {
+	set := make(map[Edge]bool, len(edges))
+	for _, edge := range edges {
+		set[edge.normalized()] = true
+	}
+	return set
+}
@@ -0,0 +1,176 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// A uniform grid over the points being triangulated, recording which cells each
+// circumcircle overlaps, so that an insertion only tests the circumcircles that
+// overlap the new point's cell
+// Entries are positions in Triangulation.circles. Circumcircles that would cover
+// too many cells, or that are too thin to bound, are kept in oversize and tested
+// on every insertion instead.
+type circleGrid struct {
+	min           Point
+	cell_size     float64
+	columns, rows int
+	cells         [][]int
+	oversize      []int
+}
+
+// Most cells a circumcircle is recorded in before it is treated as oversize
+const grid_max_cells = 64
+
+// Given the corners of the bounding box of the points and how many there are,
+// return an empty grid with roughly one cell per point
+func newCircleGrid(min, max Point, count int) *circleGrid {
+	side := math.Ceil(math.Sqrt(float64(count)))
+	size := math.Max(max.X-min.X, max.Y-min.Y) / side
+	if size == 0 {
+		size = 1
+	}
+
+	g := &circleGrid{
+		min:       min,
+		cell_size: size,
+		columns:   int((max.X-min.X)/size) + 1,
+		rows:      int((max.Y-min.Y)/size) + 1,
+	}
+	g.cells = make([][]int, g.columns*g.rows)
+	return g
+}
+
+// circleGrid method
+// Return: The column of x, clamped to the grid
+func (g *circleGrid) column(x float64) int {
+	return clampIndex(math.Floor((x-g.min.X)/g.cell_size), g.columns)
+}
+
+// circleGrid method
+// Return: The row of y, clamped to the grid
+func (g *circleGrid) row(y float64) int {
+	return clampIndex(math.Floor((y-g.min.Y)/g.cell_size), g.rows)
+}
+
+// Given a cell coordinate and the number of cells, clamp it to the grid
+func clampIndex(i float64, count int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= float64(count) {
+		return count - 1
+	}
+	return int(i)
+}
+
+// circleGrid method
+// Finds the cells a circumcircle must be recorded in: every cell overlapping the
+// square around the circle, widened by epsilon and by the circle's slack
+// Return: The lists the circle's position belongs in
+func (g *circleGrid) lists(c circumTriangle, epsilon float64) []*[]int {
+	if c.radius_sq < 0 {
+		// Contains no point, so is never tested
+		return nil
+	}
+	if math.IsInf(c.slack, 1) {
+		return []*[]int{&g.oversize}
+	}
+
+	reach := (math.Sqrt(c.radius_sq+c.slack) + epsilon) * (1 + 1e-9)
+	if c.center.X+reach < g.min.X || c.center.Y+reach < g.min.Y ||
+		c.center.X-reach > g.min.X+float64(g.columns)*g.cell_size ||
+		c.center.Y-reach > g.min.Y+float64(g.rows)*g.cell_size {
+		// Entirely outside the grid, which holds every point still to come
+		return nil
+	}
+
+	first_column, last_column := g.column(c.center.X-reach), g.column(c.center.X+reach)
+	first_row, last_row := g.row(c.center.Y-reach), g.row(c.center.Y+reach)
+	if (last_column-first_column+1)*(last_row-first_row+1) > grid_max_cells {
+		return []*[]int{&g.oversize}
+	}
+
+	var lists []*[]int
+	for row := first_row; row <= last_row; row++ {
+		for column := first_column; column <= last_column; column++ {
+			lists = append(lists, &g.cells[row*g.columns+column])
+		}
+	}
+	return lists
+}
+
+// circleGrid method
+// Records a circumcircle at a position of Triangulation.circles
+func (g *circleGrid) add(c circumTriangle, position int, epsilon float64) {
+	for _, list := range g.lists(c, epsilon) {
+		*list = append(*list, position)
+	}
+}
+
+// circleGrid method
+// Changes the position a circumcircle is recorded at, or forgets it if to is -1
+func (g *circleGrid) move(c circumTriangle, from, to int, epsilon float64) {
+	for _, list := range g.lists(c, epsilon) {
+		for k, position := range *list {
+			if position != from {
+				continue
+			}
+			if to >= 0 {
+				(*list)[k] = to
+			} else {
+				(*list)[k] = (*list)[len(*list)-1]
+				*list = (*list)[:len(*list)-1]
+			}
+			break
+		}
+	}
+}
+
+// Triangulation method
+// Insert using the grid: only circumcircles overlapping p's cell are tested, and
+// each bad triangle is removed by moving the last triangle into its place
+func (t *Triangulation) insertIndexed(p Point) {
+	g := t.grid
+	cell := g.cells[g.row(p.Y)*g.columns+g.column(p.X)]
+
+	t.bad_positions = t.bad_positions[:0]
+	for _, candidates := range [2][]int{cell, g.oversize} {
+		for _, position := range candidates {
+			if t.isBad(t.circles[position], p) {
+				t.bad_positions = append(t.bad_positions, position)
+			}
+		}
+	}
+
+	// Removing from the highest position down means the triangle moved into
+	// each hole is never one still waiting to be removed
+	sort.Sort(sort.Reverse(sort.IntSlice(t.bad_positions)))
+
+	t.edges = t.edges[:0]
+	for _, position := range t.bad_positions {
+		triangle := t.circles[position].triangle
+		t.edges = append(t.edges, Edge{triangle.A, triangle.B}, Edge{triangle.A, triangle.C}, Edge{triangle.B, triangle.C})
+
+		last := len(t.circles) - 1
+		g.move(t.circles[position], position, -1, t.opts.Epsilon)
+		if position != last {
+			g.move(t.circles[last], last, position, t.opts.Epsilon)
+			t.circles[position] = t.circles[last]
+		}
+		t.circles = t.circles[:last]
+	}
+
+	edge_count := t.edgeCount()
+	for _, edge := range t.edges {
+		edge_count[edge.normalized()]++
+	}
+
+	for _, edge := range t.edges {
+		if edge_count[edge.normalized()] == 1 {
+			circle := newCircumTriangle(Triangle{edge.A, edge.B, p})
+			g.add(circle, len(t.circles), t.opts.Epsilon)
+			t.circles = append(t.circles, circle)
+		}
+	}
+}
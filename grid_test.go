@@ -0,0 +1,64 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Return: n points in a few tight clusters spread over the unit square, with a
+// fixed seed
+func clusteredPoints(seed int64, n int) []Point {
+	r := rand.New(rand.NewSource(seed))
+	centers := make([]Point, 5)
+	for i := range centers {
+		centers[i] = Point{r.Float64(), r.Float64()}
+	}
+	points := make([]Point, n)
+	for i := range points {
+		center := centers[r.Intn(len(centers))]
+		points[i] = Point{center.X + 0.01*r.NormFloat64(), center.Y + 0.01*r.NormFloat64()}
+	}
+	return points
+}
+
+func TestGridMatchesBruteForce(t *testing.T) {
+	tests := map[string][]Point{
+		"random":    randomPoints(7, 2000),
+		"clustered": clusteredPoints(7, 2000),
+		"lattice":   latticePoints(20),
+		"thin":      {{0, 0}, {1e6, 0}, {5e5, 1e-3}, {2e5, -1e-3}, {7e5, 2e-3}},
+	}
+	for name, points := range tests {
+		want, err := TriangulateWithOptions(points, Options{Sorted: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := TriangulateWithOptions(points, Options{Sorted: true, Grid: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: the grid gave %d triangles, brute force %d", name, len(got), len(want))
+		}
+	}
+}
+
+func benchmarkClustered(b *testing.B, n int, opts Options) {
+	points := clusteredPoints(1, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := TriangulateWithOptions(points, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClusteredBruteForce10k(b *testing.B) {
+	benchmarkClustered(b, 10000, Options{})
+}
+
+func BenchmarkClusteredGrid10k(b *testing.B) {
+	benchmarkClustered(b, 10000, Options{Grid: true})
+}
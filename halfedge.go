@@ -0,0 +1,121 @@
+package bowyer_watson
+
+// A triangulation as a half-edge structure (doubly connected edge list), for
+// walking between vertices, edges and faces without searching
+// Every edge of a face is a half-edge directed counter-clockwise around it, and
+// the two half-edges of an edge shared by two faces are each other's twin.
+type HalfEdgeMesh struct {
+	Vertices  []HalfEdgeVertex
+	HalfEdges []HalfEdge
+	Faces     []HalfEdgeFace
+}
+
+// One side of an edge, directed from Origin to Next.Origin
+type HalfEdge struct {
+	Origin *HalfEdgeVertex
+	// The half-edge in the opposite direction on the neighboring face, or nil if
+	// the edge is on the boundary
+	Twin *HalfEdge
+	// The half-edges before and after this one counter-clockwise around Face
+	Next, Prev *HalfEdge
+	Face       *HalfEdgeFace
+}
+
+// A vertex of a HalfEdgeMesh
+type HalfEdgeVertex struct {
+	Point
+	// A half-edge starting at the vertex. For a vertex on the boundary it is the
+	// one with no twin, so that following Prev.Twin from it visits every face
+	// around the vertex counter-clockwise.
+	Edge *HalfEdge
+}
+
+// A triangle of a HalfEdgeMesh
+type HalfEdgeFace struct {
+	// The half-edge from the triangle's first vertex
+	Edge *HalfEdge
+}
+
+// Given the triangles of a triangulation, return its half-edge structure
+// Each triangle becomes a face, in the same order, and clockwise triangles are
+// made counter-clockwise first. Vertices are in the order they are first seen, as
+// for IndexTriangles. An edge used in the same direction by two faces, as when
+// triangles overlap, is left without a twin.
+// Return: The mesh, whose records all point into its own slices
+func BuildHalfEdge(triangles []Triangle) *HalfEdgeMesh {
+	ccw := make([]Triangle, len(triangles))
+	for i, triangle := range triangles {
+		ccw[i] = triangle.ToCCW()
+	}
+	vertices, faces := IndexTriangles(ccw)
+
+	m := &HalfEdgeMesh{
+		Vertices:  make([]HalfEdgeVertex, len(vertices)),
+		HalfEdges: make([]HalfEdge, 3*len(faces)),
+		Faces:     make([]HalfEdgeFace, len(faces)),
+	}
+	for i, p := range vertices {
+		m.Vertices[i].Point = p
+	}
+
+	// Each directed edge, by the indices of its ends, to its half-edge
+	type directed struct {
+		from, to int
+	}
+	by_ends := make(map[directed]*HalfEdge, 3*len(faces))
+	duplicated := make(map[directed]bool)
+
+	for i, face := range faces {
+		m.Faces[i].Edge = &m.HalfEdges[3*i]
+		for k := 0; k < 3; k++ {
+			e := &m.HalfEdges[3*i+k]
+			e.Origin = &m.Vertices[face[k]]
+			e.Next = &m.HalfEdges[3*i+(k+1)%3]
+			e.Prev = &m.HalfEdges[3*i+(k+2)%3]
+			e.Face = &m.Faces[i]
+
+			ends := directed{face[k], face[(k+1)%3]}
+			if _, ok := by_ends[ends]; ok {
+				duplicated[ends] = true
+			}
+			by_ends[ends] = e
+		}
+	}
+
+	for ends, e := range by_ends {
+		if duplicated[ends] || duplicated[directed{ends.to, ends.from}] {
+			continue
+		}
+		if twin, ok := by_ends[directed{ends.to, ends.from}]; ok {
+			e.Twin = twin
+		}
+	}
+
+	for i := range m.HalfEdges {
+		e := &m.HalfEdges[i]
+		if e.Origin.Edge == nil || e.Twin == nil {
+			e.Origin.Edge = e
+		}
+	}
+
+	return m
+}
+
+// HalfEdge method
+// Return: The vertex the half-edge ends at
+func (e *HalfEdge) Dest() *HalfEdgeVertex {
+	return e.Next.Origin
+}
+
+// HalfEdge method
+// Return: The half-edge as an Edge from its origin to its destination
+func (e *HalfEdge) Edge() Edge {
+	return Edge{e.Origin.Point, e.Dest().Point}
+}
+
+// HalfEdgeFace method
+// Return: The face as a counter-clockwise Triangle
+func (f *HalfEdgeFace) Triangle() Triangle {
+	e := f.Edge
+	return Triangle{e.Origin.Point, e.Next.Origin.Point, e.Prev.Origin.Point}
+}
@@ -0,0 +1,64 @@
+package bowyer_watson
+
+import "testing"
+
+func TestBuildHalfEdgeInvariants(t *testing.T) {
+	points := append(randomPoints(73, 200), latticePoints(4)...)
+	triangles := mustTriangulate(t, points)
+	// Some triangles clockwise, which BuildHalfEdge turns around
+	for i := 0; i < len(triangles); i += 3 {
+		triangles[i].B, triangles[i].C = triangles[i].C, triangles[i].B
+	}
+	m := BuildHalfEdge(triangles)
+	mesh := NewMesh(triangles)
+
+	if len(m.Faces) != len(triangles) || len(m.HalfEdges) != 3*len(triangles) || len(m.Vertices) != len(mesh.Vertices) {
+		t.Fatalf("got %d faces, %d half-edges and %d vertices, want %d, %d and %d", len(m.Faces), len(m.HalfEdges),
+			len(m.Vertices), len(triangles), 3*len(triangles), len(mesh.Vertices))
+	}
+	for i := range m.Faces {
+		face := m.Faces[i].Triangle()
+		if !face.Equal(triangles[i]) || face.Orientation() != CCW {
+			t.Errorf("face %d is %v, want %v counter-clockwise", i, face, triangles[i])
+		}
+	}
+
+	boundary := 0
+	for i := range m.HalfEdges {
+		e := &m.HalfEdges[i]
+		if e.Next.Next.Next != e || e.Next.Prev != e || e.Prev.Next != e {
+			t.Fatalf("%v: Next and Prev do not go around a triangle", e.Edge())
+		}
+		if e.Next.Face != e.Face || e.Prev.Face != e.Face {
+			t.Fatalf("%v: the half-edges around a face have different faces", e.Edge())
+		}
+		if e.Twin == nil {
+			boundary++
+			continue
+		}
+		if e.Twin.Twin != e || e.Twin.Origin != e.Dest() || e.Twin.Dest() != e.Origin || e.Twin.Face == e.Face {
+			t.Fatalf("%v: its twin %v does not run the other way on another face", e.Edge(), e.Twin.Edge())
+		}
+	}
+	if want := len(ConvexHull(triangles)); boundary != want {
+		t.Errorf("%d half-edges have no twin, want the %d hull edges", boundary, want)
+	}
+
+	// Turning around each vertex by Prev.Twin visits each of its faces once
+	for i := range m.Vertices {
+		v := &m.Vertices[i]
+		if v.Edge.Origin != v {
+			t.Fatalf("vertex %v has an edge starting at %v", v.Point, v.Edge.Origin.Point)
+		}
+		count := 0
+		for e := v.Edge; count <= len(triangles); {
+			count++
+			if e = e.Prev.Twin; e == nil || e == v.Edge {
+				break
+			}
+		}
+		if want := len(mesh.AllTrianglesSharingVertex(v.Point)); count != want {
+			t.Errorf("vertex %v: turning around it visits %d faces, want %d", v.Point, count, want)
+		}
+	}
+}
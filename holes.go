@@ -0,0 +1,74 @@
+package bowyer_watson
+
+import (
+	"fmt"
+)
+
+// Given an array of points, edges between them and the boundaries of holes,
+// return a constrained triangulation with the holes cut out
+// Each hole is a polygon given by its corners in order, either clockwise or
+// counter-clockwise. Its corners are added to the points and its sides to the
+// constraints, so that ConstrainedTriangulation keeps them as edges, and then
+// every triangle whose centroid is inside a hole is removed.
+// Holes are filled by the even-odd rule: a centroid is inside when it is inside
+// an odd number of the hole polygons, so a hole drawn within another hole is
+// kept as an island, and within a single self-intersecting polygon the parts
+// wound twice are kept too. This differs from the nonzero rule, under which only
+// the winding direction would matter.
+// Return: The triangles, or an error if a hole has fewer than 3 corners or the
+// constrained triangulation fails, see ConstrainedTriangulation
+func TriangulateWithHoles(points []Point, constraints []Edge, holes [][]Point) ([]Triangle, error) {
+	all_points := append([]Point(nil), points...)
+	all_constraints := append([]Edge(nil), constraints...)
+	for i, hole := range holes {
+		if len(hole) < 3 {
+			return nil, fmt.Errorf("bowyer_watson: hole %d needs at least 3 corners, got %d", i, len(hole))
+		}
+		all_points = append(all_points, hole...)
+		for k, p := range hole {
+			q := hole[(k+1)%len(hole)]
+			if p != q {
+				all_constraints = append(all_constraints, Edge{p, q})
+			}
+		}
+	}
+
+	triangles, err := ConstrainedTriangulation(all_points, all_constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := triangles[:0]
+	for _, triangle := range triangles {
+		centroid := triangle.Centroid()
+		inside := false
+		for _, hole := range holes {
+			if insidePolygon(hole, centroid) {
+				inside = !inside
+			}
+		}
+		if !inside {
+			kept = append(kept, triangle)
+		}
+	}
+	return kept, nil
+}
+
+// Given a polygon and a point, determine if the point is inside the polygon by the
+// even-odd rule, counting the sides crossed by a ray from the point towards +X
+// Points exactly on a side may be counted either way
+// Return: True if the ray crosses an odd number of sides
+func insidePolygon(polygon []Point, p Point) bool {
+	inside := false
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		// The ray crosses when p is on the left of the upward side
+		if (Orient2D(a, b, p) > 0) == (b.Y > a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,50 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTriangulateWithHolesSquareRing(t *testing.T) {
+	outer := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := []Point{{3, 3}, {3, 7}, {7, 7}, {7, 3}}
+	points := append([]Point(nil), outer...)
+	for _, p := range randomPoints(59, 60) {
+		points = append(points, Point{p.X * 10, p.Y * 10})
+	}
+
+	triangles, err := TriangulateWithHoles(points, nil, [][]Point{hole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := TotalArea(triangles); math.Abs(area-84) > 1e-9 {
+		t.Errorf("the ring covers %v, want 84", area)
+	}
+	for _, triangle := range triangles {
+		for _, p := range []Point{triangle.A, triangle.B, triangle.C, triangle.Centroid()} {
+			if p.X > 3 && p.X < 7 && p.Y > 3 && p.Y < 7 {
+				t.Errorf("%v reaches into the hole at %v", triangle, p)
+			}
+		}
+	}
+	edges := edgeSet(triangles)
+	for _, side := range polygonEdges(hole) {
+		if !edges[side.normalized()] {
+			t.Errorf("the hole's side %v is not an edge", side)
+		}
+	}
+
+	// A hole inside the hole is an island, by the even-odd rule
+	island := []Point{{4, 4}, {6, 4}, {6, 6}, {4, 6}}
+	triangles, err = TriangulateWithHoles(outer, nil, [][]Point{hole, island})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := TotalArea(triangles); math.Abs(area-88) > 1e-9 {
+		t.Errorf("the ring and island cover %v, want 88", area)
+	}
+
+	if _, err := TriangulateWithHoles(outer, nil, [][]Point{{{3, 3}, {7, 7}}}); err == nil {
+		t.Error("TriangulateWithHoles() accepted a hole with 2 corners")
+	}
+}
@@ -0,0 +1,195 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// Given the triangles of a triangulation, return the points on its boundary
+// The boundary is made of the edges that belong to exactly one triangle. For a
+// triangulation that covers the convex hull of its points, as those of Triangulate
+// and TriangulateDivideConquer do, this is the convex hull, though unlike
+// ConvexHullOfPoints it also has any points lying on a hull edge between two
+// corners. Triangles missing along the hull, as when a super triangle passed to
+// DelaunayTriangulation is too close, leave a boundary that is not convex.
+// Return: The boundary in counter-clockwise order, starting from the point with
+// the smallest X (and then smallest Y), or nil if there are no triangles
+func ConvexHull(triangles []Triangle) []Point {
+	next := make(map[Point]Point)
+	for _, edge := range boundaryEdges(triangles) {
+		next[edge.A] = edge.B
+	}
+
+	if len(next) == 0 {
+		return nil
+	}
+
+	var start Point
+	first := true
+	for p := range next {
+		if first || pointLess(p, start) {
+			start = p
+			first = false
+		}
+	}
+
+	hull := []Point{start}
+	for p, ok := next[start]; ok && p != start && len(hull) < len(next); p, ok = next[p] {
+		hull = append(hull, p)
+	}
+
+	return hull
+}
+
+// Given an array of points, return their convex hull without triangulating them
+// The hull is found directly by Andrew's monotone chain, in O(n log n) time, which
+// is much faster than ConvexHull when the triangles are not otherwise needed.
+// Points lying on a hull edge between two corners are left out, as are repeated
+// points, so only the corners are returned; ConvexHull of a triangulation keeps
+// such points since they are vertices on its boundary.
+// Return: The corners in counter-clockwise order, starting from the point with the
+// smallest X (and then smallest Y) as ConvexHull does. If the points are all
+// collinear, the two ends of the line, or the single distinct point; nil if there
+// are no points
+func ConvexHullOfPoints(points []Point) []Point {
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return pointLess(sorted[i], sorted[j]) })
+
+	distinct := sorted[:0]
+	for _, p := range sorted {
+		if len(distinct) == 0 || distinct[len(distinct)-1] != p {
+			distinct = append(distinct, p)
+		}
+	}
+	if len(distinct) < 3 {
+		return distinct
+	}
+
+	// The lower chain runs left to right and the upper chain back again, each
+	// dropping points that do not make a strict left turn
+	hull := make([]Point, 0, 2*len(distinct))
+	for i := 0; i < len(distinct); i++ {
+		for len(hull) >= 2 && Orient2D(hull[len(hull)-2], hull[len(hull)-1], distinct[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, distinct[i])
+	}
+	lower := len(hull)
+	for i := len(distinct) - 2; i >= 0; i-- {
+		for len(hull) > lower && Orient2D(hull[len(hull)-2], hull[len(hull)-1], distinct[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, distinct[i])
+	}
+
+	// The last point is the first one again
+	return hull[:len(hull)-1]
+}
+
+// Given an array of distinct points, not all on one line, count the points on the
+// boundary of their convex hull
+// This is the monotone chain of ConvexHullOfPoints, except that points lying on a
+// hull edge between two corners are kept and counted too.
+// Return: The number of points on the boundary
+func hullPointCount(points []Point) int {
+	sorted := append([]Point(nil), points...)
+	count, _ := hullPointCountInPlace(sorted, nil)
+	return count
+}
+
+// As hullPointCount, but sorting the points in place and building the chain in
+// the memory of chain
+// Return: The number of points on the boundary, and the chain's memory for reuse
+func hullPointCountInPlace(sorted, chain []Point) (int, []Point) {
+	sort.Slice(sorted, func(i, j int) bool { return pointLess(sorted[i], sorted[j]) })
+
+	count := 0
+	for _, order := range [2]int{1, -1} {
+		chain = chain[:0]
+		for i := range sorted {
+			p := sorted[i]
+			if order < 0 {
+				p = sorted[len(sorted)-1-i]
+			}
+			for len(chain) >= 2 && Orient2D(chain[len(chain)-2], chain[len(chain)-1], p) < 0 {
+				chain = chain[:len(chain)-1]
+			}
+			chain = append(chain, p)
+		}
+		// Each chain ends where the other starts
+		count += len(chain) - 1
+	}
+	return count, chain
+}
+
+// Given an array of points and a radius, return the boundary of their alpha shape
+// The alpha shape keeps the Delaunay triangles whose circumradius is at most alpha,
+// and its boundary is made of the edges belonging to exactly one kept triangle.
+// Small values of alpha carve concavities and holes out of the point set, while
+// a large enough alpha keeps every triangle. As Triangulate covers the convex
+// hull, the boundary is then the hull, with the edges split at any points lying
+// on them as in ConvexHull.
+// Return: The boundary edges, each directed counter-clockwise around the shape, or
+// nil if the points cannot be triangulated
+func AlphaShape(points []Point, alpha float64) []Edge {
+	triangles, err := Triangulate(points)
+	if err != nil {
+		return nil
+	}
+
+	kept := triangles[:0]
+	for _, triangle := range triangles {
+		if triangle.CircumRadius() <= alpha {
+			kept = append(kept, triangle)
+		}
+	}
+	return boundaryEdges(kept)
+}
+
+// Given an array of triangles and a length, return the triangles whose edges are
+// all at most that long
+// This drops the long slivers that span gaps in sparse or noisy data, and is a
+// cheap stand-in for the alpha shape, which bounds the circumradius instead.
+// Return: The kept triangles in their original order, in a new slice
+func FilterByMaxEdge(triangles []Triangle, max_length float64) []Triangle {
+	var kept []Triangle
+	for _, triangle := range triangles {
+		longest := 0.0
+		for _, edge := range triangle.edges() {
+			longest = math.Max(longest, edge.Length())
+		}
+		if longest <= max_length {
+			kept = append(kept, triangle)
+		}
+	}
+	return kept
+}
+
+// Given an array of triangles, return the edges belonging to exactly one of them
+// Walking each triangle counter-clockwise walks its boundary edges in the same
+// direction as the boundary itself, so each edge is directed that way
+// Return: The edges, in the order of the triangles they belong to
+func boundaryEdges(triangles []Triangle) []Edge {
+	edge_count := make(map[Edge]int, 3*len(triangles))
+	for _, triangle := range triangles {
+		for _, edge := range triangle.edges() {
+			edge_count[edge.normalized()]++
+		}
+	}
+
+	var boundary []Edge
+	for _, triangle := range triangles {
+		for _, edge := range triangle.ToCCW().edges() {
+			if edge_count[edge.normalized()] == 1 {
+				boundary = append(boundary, edge)
+			}
+		}
+	}
+	return boundary
+}
+
+// Triangle method
+// Return: The 3 edges of the triangle, following the vertices in order A, B, C
+func (t Triangle) edges() [3]Edge {
+	return [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}}
+}
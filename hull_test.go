@@ -0,0 +1,192 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHullPointCount(t *testing.T) {
+	tests := []struct {
+		points []Point
+		want   int
+	}{
+		{[]Point{{0, 0}, {1, 0}, {0, 1}}, 3},
+		{[]Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}}, 4},
+		{[]Point{{0, 0}, {1, 0}, {2, 0}, {1, 1}}, 4},
+		{[]Point{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}, 6},
+		{latticePoints(5), 16},
+	}
+	for _, test := range tests {
+		if got := hullPointCount(test.points); got != test.want {
+			t.Errorf("hullPointCount(%v) = %d, want %d", test.points, got, test.want)
+		}
+	}
+
+	// Every triangulation of n points with h on the hull has 2n - h - 2 triangles
+	for seed := int64(0); seed < 50; seed++ {
+		points := append(randomPoints(seed, 20), latticePoints(3)...)
+		triangles, err := TriangulateDivideConquer(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := hullPointCount(points), 2*len(points)-len(triangles)-2; got != want {
+			t.Errorf("seed %d: hullPointCount() = %d, want %d", seed, got, want)
+		}
+	}
+}
+
+// Return: The hull without the points lying on an edge between two corners
+func hullCorners(hull []Point) []Point {
+	var corners []Point
+	for i, p := range hull {
+		prev, next := hull[(i+len(hull)-1)%len(hull)], hull[(i+1)%len(hull)]
+		if Orient2D(prev, p, next) != 0 {
+			corners = append(corners, p)
+		}
+	}
+	return corners
+}
+
+func TestConvexHull(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {4, 3}, {0, 3}, {1, 1}, {2, 2}, {3, 1}, {2, 4}, {2, 0}}
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Point{{0, 0}, {2, 0}, {4, 0}, {4, 3}, {2, 4}, {0, 3}}
+	if got := ConvexHull(triangles); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConvexHull() = %v, want %v", got, want)
+	}
+	if got := ConvexHull(nil); got != nil {
+		t.Errorf("ConvexHull(nil) = %v, want nil", got)
+	}
+}
+
+func TestConvexHullOfPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   []Point
+	}{
+		{"none", nil, nil},
+		{"one", []Point{{2, 3}}, []Point{{2, 3}}},
+		{"repeated", []Point{{2, 3}, {2, 3}, {2, 3}}, []Point{{2, 3}}},
+		{"collinear", []Point{{1, 1}, {3, 3}, {0, 0}, {2, 2}}, []Point{{0, 0}, {3, 3}}},
+		{"triangle", []Point{{0, 1}, {1, 0}, {0, 0}}, []Point{{0, 0}, {1, 0}, {0, 1}}},
+		{"square with inside and edge points", []Point{{1, 1}, {0, 2}, {2, 2}, {0, 0}, {1, 0}, {2, 0}, {0, 1}, {0, 0}},
+			[]Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}}},
+		{"diamond", []Point{{0, 1}, {1, 0}, {2, 1}, {1, 2}, {1, 1}}, []Point{{0, 1}, {1, 0}, {2, 1}, {1, 2}}},
+	}
+	for _, test := range tests {
+		if got := ConvexHullOfPoints(test.points); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ConvexHullOfPoints() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestConvexHullMatchesConvexHullOfPoints(t *testing.T) {
+	for seed := int64(0); seed < 300; seed++ {
+		points := randomPoints(seed, 30)
+		if seed%2 == 0 {
+			points = append(points, latticePoints(4)...)
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, want := hullCorners(ConvexHull(triangles)), ConvexHullOfPoints(points)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("seed %d: ConvexHull() corners = %v, ConvexHullOfPoints() = %v", seed, got, want)
+		}
+	}
+}
+
+func TestAlphaShapeLargeAlphaIsHull(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		points := randomPoints(seed, 30)
+		if seed%2 == 0 {
+			points = append(points, latticePoints(3)...)
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := AlphaShape(points, math.Inf(1))
+		if want := boundaryEdges(triangles); !reflect.DeepEqual(got, want) {
+			t.Fatalf("seed %d: AlphaShape() = %v, want %v", seed, got, want)
+		}
+
+		var length, perimeter float64
+		for _, edge := range got {
+			length += edge.Length()
+		}
+		hull := ConvexHullOfPoints(points)
+		for i, p := range hull {
+			perimeter += p.Distance(hull[(i+1)%len(hull)])
+		}
+		if math.Abs(length-perimeter) > 1e-9*perimeter {
+			t.Fatalf("seed %d: alpha shape boundary is %v long, hull perimeter %v", seed, length, perimeter)
+		}
+	}
+}
+
+func TestAlphaShapeCarvesHole(t *testing.T) {
+	// Two rings of points around an empty middle
+	var points []Point
+	for i := 0; i < 24; i++ {
+		angle := 2 * math.Pi * float64(i) / 24
+		points = append(points, Point{10 * math.Cos(angle), 10 * math.Sin(angle)})
+		points = append(points, Point{9 * math.Cos(angle+math.Pi/24), 9 * math.Sin(angle+math.Pi/24)})
+	}
+	// Only the thin triangles between the rings are small enough to keep
+	edges := AlphaShape(points, 2)
+	if len(edges) != 48 {
+		t.Fatalf("AlphaShape() has %d edges, want 48", len(edges))
+	}
+	for _, edge := range edges {
+		if r := edge.Midpoint().Distance(Point{}); r < 8 {
+			t.Errorf("edge %v crosses the hole", edge)
+		}
+	}
+}
+
+func TestFilterByMaxEdge(t *testing.T) {
+	// Two clusters of close points, far apart, joined by long slivers
+	cluster := randomPoints(71, 40)
+	var points []Point
+	for _, p := range cluster {
+		points = append(points, p, Point{p.X + 20, p.Y})
+	}
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kept := FilterByMaxEdge(triangles, 1.5)
+	if len(kept) == 0 || len(kept) == len(triangles) {
+		t.Fatalf("kept %d of %d triangles, want only the clusters", len(kept), len(triangles))
+	}
+	for _, triangle := range kept {
+		for _, edge := range triangle.edges() {
+			if edge.Length() > 1.5 {
+				t.Errorf("%v has the edge %v of length %v", triangle, edge, edge.Length())
+			}
+		}
+	}
+	// No edge within a cluster is longer than the diagonal of the unit square, so
+	// both are kept whole
+	area := 2 * PolygonArea(ConvexHullOfPoints(cluster))
+	if got := TotalArea(kept); math.Abs(got-area) > 1e-9 {
+		t.Errorf("the kept triangles cover %v, want %v", got, area)
+	}
+
+	// An edge exactly at the limit is kept
+	right := Triangle{Point{0, 0}, Point{3, 0}, Point{0, 4}}
+	if got := FilterByMaxEdge([]Triangle{right}, 5); len(got) != 1 {
+		t.Errorf("FilterByMaxEdge(%v, 5) = %v, want it kept", right, got)
+	}
+	if got := FilterByMaxEdge([]Triangle{right}, 4.9); got != nil {
+		t.Errorf("FilterByMaxEdge(%v, 4.9) = %v, want nil", right, got)
+	}
+}
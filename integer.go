@@ -0,0 +1,153 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Basic x,y coordinate on an integer lattice, such as a pixel position
+type IntPoint struct {
+	X, Y int64
+}
+
+// Triangle with integer vertices
+type IntTriangle struct {
+	A, B, C IntPoint
+}
+
+// IntPoint method
+// Return: The point as a float64 Point, which is exact for coordinates up to 2^53
+func (p IntPoint) Float64() Point {
+	return Point{float64(p.X), float64(p.Y)}
+}
+
+// Largest coordinate for which the predicates below cannot overflow an int64
+const int_orient_limit = 1 << 30
+const int_incircle_limit = 1 << 12
+
+// Given an array of integer points, return an array of triangles of their
+// triangulation
+// Every predicate is evaluated exactly in integer arithmetic, switching to
+// math/big when the coordinates are too large for an int64, so lattice inputs with
+// many collinear and cocircular points are always handled correctly. The
+// triangulation is built by divide and conquer, as in TriangulateDivideConquer,
+// so no super triangle is needed and any int64 coordinates can be used. Repeated
+// points are triangulated once.
+// Return: The triangles, or an error wrapping ErrTooFewPoints or ErrCollinearPoints
+func TriangulateInt(points []IntPoint) ([]IntTriangle, error) {
+	if len(points) < 3 {
+		return nil, fmt.Errorf("%w: need at least 3 points, got %d", ErrTooFewPoints, len(points))
+	}
+
+	sorted := append([]IntPoint(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].X < sorted[j].X || sorted[i].X == sorted[j].X && sorted[i].Y < sorted[j].Y
+	})
+	distinct := sorted[:1]
+	for _, p := range sorted[1:] {
+		if p != distinct[len(distinct)-1] {
+			distinct = append(distinct, p)
+		}
+	}
+	if len(distinct) < 3 {
+		return nil, fmt.Errorf("%w: need at least 3 distinct points, got %d", ErrTooFewPoints, len(distinct))
+	}
+
+	collinear := true
+	for _, p := range distinct[2:] {
+		if orientInt(distinct[0], distinct[1], p) != 0 {
+			collinear = false
+			break
+		}
+	}
+	if collinear {
+		return nil, fmt.Errorf("%w: all %d points lie on a single line", ErrCollinearPoints, len(points))
+	}
+
+	m := &quadEdges{
+		orient: func(a, b, c int) int {
+			return orientInt(distinct[a], distinct[b], distinct[c])
+		},
+		incircle: func(a, b, c, d int) int {
+			return incircleInt(distinct[a], distinct[b], distinct[c], distinct[d])
+		},
+	}
+	m.delaunay(0, len(distinct))
+
+	faces := m.triangles()
+	triangles := make([]IntTriangle, len(faces))
+	for i, face := range faces {
+		triangles[i] = IntTriangle{distinct[face[0]], distinct[face[1]], distinct[face[2]]}
+	}
+	return triangles, nil
+}
+
+// Given points, determine if every coordinate is within limit of zero
+func withinLimit(limit int64, points ...IntPoint) bool {
+	for _, p := range points {
+		if p.X > limit || p.X < -limit || p.Y > limit || p.Y < -limit {
+			return false
+		}
+	}
+	return true
+}
+
+// Given three integer points, determine the orientation of a, b, c exactly
+// Return: 1 when counter-clockwise, -1 when clockwise and 0 when collinear
+func orientInt(a, b, c IntPoint) int {
+	if withinLimit(int_orient_limit, a, b, c) {
+		det := (a.X-c.X)*(b.Y-c.Y) - (a.Y-c.Y)*(b.X-c.X)
+		return signInt(det)
+	}
+
+	ax, ay, bx, by, cx, cy := big.NewInt(a.X), big.NewInt(a.Y), big.NewInt(b.X), big.NewInt(b.Y), big.NewInt(c.X), big.NewInt(c.Y)
+	left := new(big.Int).Mul(new(big.Int).Sub(ax, cx), new(big.Int).Sub(by, cy))
+	right := new(big.Int).Mul(new(big.Int).Sub(ay, cy), new(big.Int).Sub(bx, cx))
+	return left.Cmp(right)
+}
+
+// Given four integer points, determine exactly where d lies relative to the circle
+// through a, b and c
+// Return: 1 when d is inside the circle and a, b, c are counter-clockwise, -1 when
+// it is outside, and 0 when the four points are cocircular. The sign is reversed
+// when a, b, c are clockwise.
+func incircleInt(a, b, c, d IntPoint) int {
+	if withinLimit(int_incircle_limit, a, b, c, d) {
+		adx, ady := a.X-d.X, a.Y-d.Y
+		bdx, bdy := b.X-d.X, b.Y-d.Y
+		cdx, cdy := c.X-d.X, c.Y-d.Y
+		det := (adx*adx+ady*ady)*(bdx*cdy-cdx*bdy) +
+			(bdx*bdx+bdy*bdy)*(cdx*ady-adx*cdy) +
+			(cdx*cdx+cdy*cdy)*(adx*bdy-bdx*ady)
+		return signInt(det)
+	}
+
+	diff := func(p, q int64) *big.Int { return new(big.Int).Sub(big.NewInt(p), big.NewInt(q)) }
+	adx, ady := diff(a.X, d.X), diff(a.Y, d.Y)
+	bdx, bdy := diff(b.X, d.X), diff(b.Y, d.Y)
+	cdx, cdy := diff(c.X, d.X), diff(c.Y, d.Y)
+
+	lift := func(x, y *big.Int) *big.Int {
+		return new(big.Int).Add(new(big.Int).Mul(x, x), new(big.Int).Mul(y, y))
+	}
+	cross := func(x1, y1, x2, y2 *big.Int) *big.Int {
+		return new(big.Int).Sub(new(big.Int).Mul(x1, y2), new(big.Int).Mul(x2, y1))
+	}
+
+	det := new(big.Int).Mul(lift(adx, ady), cross(bdx, bdy, cdx, cdy))
+	det.Add(det, new(big.Int).Mul(lift(bdx, bdy), cross(cdx, cdy, adx, ady)))
+	det.Add(det, new(big.Int).Mul(lift(cdx, cdy), cross(adx, ady, bdx, bdy)))
+	return det.Sign()
+}
+
+// Given an integer, return its sign: -1, 0 or 1
+func signInt(x int64) int {
+	if x > 0 {
+		return 1
+	}
+	if x < 0 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTriangulateIntLattice(t *testing.T) {
+	for _, scale := range []int64{1, 1 << 20, 1 << 40} {
+		var points []IntPoint
+		for i := int64(0); i < 20; i++ {
+			for j := int64(0); j < 20; j++ {
+				points = append(points, IntPoint{i * scale, j * scale})
+			}
+		}
+		triangles, err := TriangulateInt(points)
+		if err != nil {
+			t.Fatalf("scale %d: %v", scale, err)
+		}
+
+		converted := make([]Triangle, len(triangles))
+		for i, triangle := range triangles {
+			converted[i] = Triangle{triangle.A.Float64(), triangle.B.Float64(), triangle.C.Float64()}
+		}
+		if err := Validate(converted); err != nil {
+			t.Errorf("scale %d: %v", scale, err)
+		}
+		area := 361 * float64(scale) * float64(scale)
+		if len(triangles) != 722 || math.Abs(TotalArea(converted)-area) > 1e-9*area {
+			t.Errorf("scale %d: got %d triangles covering %v, want 722 covering %v",
+				scale, len(triangles), TotalArea(converted), area)
+		}
+	}
+}
+
+func TestIntPredicatesMatchFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 2000; i++ {
+		var p [4]IntPoint
+		for k := range p {
+			p[k] = IntPoint{r.Int63n(4000) - 2000, r.Int63n(4000) - 2000}
+		}
+		want_incircle := sign(IncircleDeterminant(p[0].Float64(), p[1].Float64(), p[2].Float64(), p[3].Float64()))
+		want_orient := sign(Orient2D(p[0].Float64(), p[1].Float64(), p[2].Float64()))
+		if got := incircleInt(p[0], p[1], p[2], p[3]); got != want_incircle {
+			t.Fatalf("incircleInt%v = %d, want %d", p, got, want_incircle)
+		}
+
+		// Scaled up past the int64 limits, so math/big is used
+		for k := range p {
+			p[k].X <<= 30
+			p[k].Y <<= 30
+		}
+		if got := incircleInt(p[0], p[1], p[2], p[3]); got != want_incircle {
+			t.Fatalf("incircleInt%v = %d, want %d", p, got, want_incircle)
+		}
+		if got := orientInt(p[0], p[1], p[2]); got != want_orient {
+			t.Fatalf("orientInt%v = %d, want %d", p[:3], got, want_orient)
+		}
+	}
+}
+
+func TestTriangulateIntErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []IntPoint
+		want   error
+	}{
+		{"none", nil, ErrTooFewPoints},
+		{"two", []IntPoint{{0, 0}, {1, 1}}, ErrTooFewPoints},
+		{"three identical", []IntPoint{{1, 1}, {1, 1}, {1, 1}}, ErrTooFewPoints},
+		{"two distinct", []IntPoint{{1, 1}, {2, 2}, {1, 1}, {2, 2}}, ErrTooFewPoints},
+		{"collinear", []IntPoint{{0, 0}, {1, 1}, {2, 2}, {2, 2}}, ErrCollinearPoints},
+	}
+	for _, test := range tests {
+		if _, err := TriangulateInt(test.points); !errors.Is(err, test.want) {
+			t.Errorf("%s: TriangulateInt() error = %v, want %v", test.name, err, test.want)
+		}
+	}
+}
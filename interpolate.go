@@ -0,0 +1,129 @@
+package bowyer_watson
+
+// Given the triangles of a triangulation and a value at each of their vertices,
+// estimate the value at a Point by linear interpolation across the triangle
+// containing it
+// Each vertex is weighted by the point's barycentric coordinates, see Barycentric,
+// so linear functions are reproduced exactly.
+// Return: The interpolated value, and false if the point is outside every triangle
+// or a vertex of its triangle has no value
+func Interpolate(triangles []Triangle, values map[Point]float64, p Point) (float64, bool) {
+	return interpolateWith(linearLocator(triangles), triangles, values, p)
+}
+
+// Interpolate, finding the triangle containing p with a locator over triangles
+func interpolateWith(l locator, triangles []Triangle, values map[Point]float64, p Point) (float64, bool) {
+	index, found := l.locate(p)
+	if !found {
+		return 0, false
+	}
+
+	triangle := triangles[index]
+	value_a, ok_a := values[triangle.A]
+	value_b, ok_b := values[triangle.B]
+	value_c, ok_c := values[triangle.C]
+	if !ok_a || !ok_b || !ok_c {
+		return 0, false
+	}
+
+	weight_a, weight_b, weight_c := triangle.Barycentric(p)
+	return weight_a*value_a + weight_b*value_b + weight_c*value_c, true
+}
+
+// Triangle method
+// Finds the barycentric coordinates of a Point, the weights of A, B and C whose
+// weighted sum is the point. Each weight is the area of the sub-triangle formed by
+// the point and the opposite edge over the area of the triangle, and the weights
+// sum to 1. They are all nonnegative exactly when the point is inside the triangle.
+// A degenerate triangle, see Degenerate, has no such weights, and gives (-1, -1, -1)
+// rather than dividing by a zero area
+// Return: The weights of A, B and C
+func (t Triangle) Barycentric(p Point) (float64, float64, float64) {
+	if t.Degenerate() {
+		return -1, -1, -1
+	}
+
+	var area = t.SignedArea()
+	var weight_a = Triangle{p, t.B, t.C}.SignedArea() / area
+	var weight_b = Triangle{t.A, p, t.C}.SignedArea() / area
+	return weight_a, weight_b, 1 - weight_a - weight_b
+}
+
+// Given sites with a value at each, estimate the value at a Point by natural
+// neighbor (Sibson) interpolation
+// The point is added to the Voronoi diagram of the sites, and each site is
+// weighted by the share of the point's new cell that was taken from the site's
+// own cell. The result matches the value at a site exactly, varies smoothly
+// between sites, and always lies between the smallest and largest value.
+// values[i] is the value at points[i]. Where a point is repeated its first
+// value is used.
+// Return: The interpolated value, and false if p is outside the convex hull of
+// the sites or values and points differ in length
+func NaturalNeighborInterpolate(points []Point, values []float64, p Point) (float64, bool) {
+	if len(points) != len(values) {
+		return 0, false
+	}
+
+	site_values := make(map[Point]float64, len(points))
+	for i, site := range points {
+		if _, ok := site_values[site]; !ok {
+			site_values[site] = values[i]
+		}
+	}
+	if value, ok := site_values[p]; ok {
+		return value, true
+	}
+
+	// The natural neighbors of p are the sites it shares an edge with once it is
+	// inserted, and the corners of its new cell are the circumcenters of the
+	// triangles around it
+	with_p := append(append([]Point(nil), points...), p)
+	super_triangle := ComputeSuperTriangle(with_p)
+	var neighbors, corners []Point
+	for _, triangle := range insertPoints(with_p, super_triangle, Options{}) {
+		if !triangle.ContainsPoint(p) {
+			continue
+		}
+		if triangle.ContainsPoint(super_triangle.A) || triangle.ContainsPoint(super_triangle.B) || triangle.ContainsPoint(super_triangle.C) {
+			// p is outside the convex hull, so its new cell is unbounded
+			return 0, false
+		}
+		corners = append(corners, triangle.Circumcenter())
+		for _, vertex := range [3]Point{triangle.A, triangle.B, triangle.C} {
+			if vertex != p && !containsPoint(neighbors, vertex) {
+				neighbors = append(neighbors, vertex)
+			}
+		}
+	}
+
+	new_cell := sortAround(p, corners)
+	total := PolygonArea(new_cell)
+	if total <= 0 {
+		return 0, false
+	}
+
+	// The part of the new cell taken from a neighbor's old cell is the part closer
+	// to that neighbor than to any of the others
+	var sum float64
+	for _, site := range neighbors {
+		stolen := new_cell
+		for _, other := range neighbors {
+			if other == site {
+				continue
+			}
+			stolen = clipCloserTo(stolen, site, other)
+		}
+		sum += PolygonArea(stolen) / total * site_values[site]
+	}
+	return sum, true
+}
+
+// Given an array of points, determine if p is one of them
+func containsPoint(points []Point, p Point) bool {
+	for _, q := range points {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInterpolateLinear(t *testing.T) {
+	points := append(randomPoints(7, 50), Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1})
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linear := func(p Point) float64 { return 3*p.X - 2*p.Y + 5 }
+	values := make(map[Point]float64, len(points))
+	for _, p := range points {
+		values[p] = linear(p)
+	}
+
+	for _, p := range append(randomPoints(8, 200), points...) {
+		got, ok := Interpolate(triangles, values, p)
+		if !ok || math.Abs(got-linear(p)) > 1e-12 {
+			t.Errorf("Interpolate(%v) = %v, %v, want %v", p, got, ok, linear(p))
+		}
+	}
+
+	if _, ok := Interpolate(triangles, values, Point{2, 0.5}); ok {
+		t.Error("Interpolate outside the triangulation succeeded")
+	}
+	delete(values, points[0])
+	index, _ := Locate(triangles, points[0])
+	if _, ok := Interpolate(triangles, values, triangles[index].Centroid()); ok {
+		t.Error("Interpolate with a vertex missing its value succeeded")
+	}
+}
+
+func TestNaturalNeighborInterpolate(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	points := append(randomPoints(14, 40), Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1})
+	values := make([]float64, len(points))
+	low, high := math.Inf(1), math.Inf(-1)
+	for i := range values {
+		values[i] = r.Float64()*15 - 5
+		low, high = math.Min(low, values[i]), math.Max(high, values[i])
+	}
+
+	for i, p := range points {
+		if got, ok := NaturalNeighborInterpolate(points, values, p); !ok || got != values[i] {
+			t.Errorf("at site %v got %v, %v, want %v", p, got, ok, values[i])
+		}
+	}
+	for _, p := range randomPoints(15, 100) {
+		got, ok := NaturalNeighborInterpolate(points, values, p)
+		if !ok || got < low-1e-9 || got > high+1e-9 {
+			t.Errorf("at %v got %v, %v, want a value between %v and %v", p, got, ok, low, high)
+		}
+	}
+
+	// Sibson's weights reproduce linear functions, like barycentric ones do
+	linear := make([]float64, len(points))
+	for i, p := range points {
+		linear[i] = 3*p.X - 2*p.Y + 5
+	}
+	for _, p := range randomPoints(16, 50) {
+		if got, ok := NaturalNeighborInterpolate(points, linear, p); !ok || math.Abs(got-(3*p.X-2*p.Y+5)) > 1e-9 {
+			t.Errorf("at %v got %v, %v, want %v", p, got, ok, 3*p.X-2*p.Y+5)
+		}
+	}
+
+	if _, ok := NaturalNeighborInterpolate(points, values, Point{1.5, 0.5}); ok {
+		t.Error("NaturalNeighborInterpolate outside the hull succeeded")
+	}
+	if _, ok := NaturalNeighborInterpolate(points, values[1:], Point{0.5, 0.5}); ok {
+		t.Error("NaturalNeighborInterpolate with too few values succeeded")
+	}
+}
+
+func TestBarycentric(t *testing.T) {
+	triangle := Triangle{Point{1, 1}, Point{4, 2}, Point{2, 5}}
+	tests := []struct {
+		p       Point
+		u, v, w float64
+	}{
+		{triangle.A, 1, 0, 0},
+		{triangle.B, 0, 1, 0},
+		{triangle.C, 0, 0, 1},
+		{triangle.Centroid(), 1.0 / 3, 1.0 / 3, 1.0 / 3},
+		{Point{2.5, 1.5}, 0.5, 0.5, 0},
+		{Point{-2, 0}, 2, -1, 0},
+	}
+	for _, test := range tests {
+		u, v, w := triangle.Barycentric(test.p)
+		if math.Abs(u-test.u) > 1e-12 || math.Abs(v-test.v) > 1e-12 || math.Abs(w-test.w) > 1e-12 {
+			t.Errorf("Barycentric(%v) = %v, %v, %v, want %v, %v, %v", test.p, u, v, w, test.u, test.v, test.w)
+		}
+		// The weights reproduce the point, whichever way the triangle is wound
+		p := Point{u*triangle.A.X + v*triangle.B.X + w*triangle.C.X, u*triangle.A.Y + v*triangle.B.Y + w*triangle.C.Y}
+		if !nearlyEqual(p, test.p) {
+			t.Errorf("Barycentric(%v) weights give %v", test.p, p)
+		}
+		if v, u, w := (Triangle{triangle.B, triangle.A, triangle.C}).Barycentric(test.p); math.Abs(u-test.u) > 1e-12 ||
+			math.Abs(v-test.v) > 1e-12 || math.Abs(w-test.w) > 1e-12 {
+			t.Errorf("clockwise Barycentric(%v) = %v, %v, %v", test.p, v, u, w)
+		}
+	}
+
+	degenerate := Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}}
+	if u, v, w := degenerate.Barycentric(Point{1, 1}); u != -1 || v != -1 || w != -1 {
+		t.Errorf("degenerate Barycentric() = %v, %v, %v, want -1, -1, -1", u, v, w)
+	}
+}
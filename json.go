@@ -0,0 +1,69 @@
+package bowyer_watson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON form of a Point
+type jsonPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Point method
+// Encodes the point as {"x":X,"y":Y}
+func (p Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPoint{p.X, p.Y})
+}
+
+// Point method
+// Decodes a point written by MarshalJSON
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var decoded jsonPoint
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*p = Point{decoded.X, decoded.Y}
+	return nil
+}
+
+// Triangle method
+// Encodes the triangle as the array of its vertices [A,B,C]
+func (t Triangle) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]Point{t.A, t.B, t.C})
+}
+
+// Triangle method
+// Decodes a triangle written by MarshalJSON
+func (t *Triangle) UnmarshalJSON(data []byte) error {
+	var vertices []Point
+	if err := json.Unmarshal(data, &vertices); err != nil {
+		return err
+	}
+	if len(vertices) != 3 {
+		return fmt.Errorf("bowyer_watson: a triangle needs 3 points, got %d", len(vertices))
+	}
+	*t = Triangle{vertices[0], vertices[1], vertices[2]}
+	return nil
+}
+
+// Edge method
+// Encodes the edge as the array of its endpoints [A,B]
+func (e Edge) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]Point{e.A, e.B})
+}
+
+// Edge method
+// Decodes an edge written by MarshalJSON
+func (e *Edge) UnmarshalJSON(data []byte) error {
+	var endpoints []Point
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return err
+	}
+	if len(endpoints) != 2 {
+		return fmt.Errorf("bowyer_watson: an edge needs 2 points, got %d", len(endpoints))
+	}
+	*e = Edge{endpoints[0], endpoints[1]}
+	return nil
+}
@@ -0,0 +1,56 @@
+package bowyer_watson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{1, 0}, Point{0, 1}},
+		{Point{-1.5, 2.25}, Point{1e9, -1e-9}, Point{math.Pi, math.E}},
+		{Point{0.1, 0.2}, Point{0.30000000000000004, math.MaxFloat64}, Point{-math.SmallestNonzeroFloat64, 7}},
+	}
+	for _, triangle := range triangles {
+		data, err := json.Marshal(triangle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Triangle
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if decoded != triangle {
+			t.Errorf("Unmarshal(%s) = %v, want %v", data, decoded, triangle)
+		}
+	}
+
+	edge := Edge{Point{1, 2}, Point{3, 4}}
+	data, err := json.Marshal(edge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[{"x":1,"y":2},{"x":3,"y":4}]` {
+		t.Errorf("Marshal(%v) = %s", edge, data)
+	}
+	var decoded Edge
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != edge {
+		t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, decoded, err, edge)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	var triangle Triangle
+	if err := json.Unmarshal([]byte(`[{"x":0,"y":0},{"x":1,"y":0}]`), &triangle); err == nil {
+		t.Error("a triangle of 2 points was accepted")
+	}
+	var edge Edge
+	if err := json.Unmarshal([]byte(`[{"x":0,"y":0}]`), &edge); err == nil {
+		t.Error("an edge of 1 point was accepted")
+	}
+	var point Point
+	if err := json.Unmarshal([]byte(`{"x":"a","y":0}`), &point); err == nil {
+		t.Error("a point with a string coordinate was accepted")
+	}
+}
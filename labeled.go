@@ -0,0 +1,45 @@
+package bowyer_watson
+
+// A Point carrying a caller-chosen payload, such as an ID or a color
+type LabeledPoint[T any] struct {
+	Point
+	Data T
+}
+
+// A Triangle whose vertices are the labeled points they came from
+type LabeledTriangle[T any] struct {
+	A, B, C LabeledPoint[T]
+}
+
+// LabeledTriangle method
+// Return: The triangle without its labels
+func (t LabeledTriangle[T]) Triangle() Triangle {
+	return Triangle{t.A.Point, t.B.Point, t.C.Point}
+}
+
+// Given an array of labeled points, return the triangles of their triangulation
+// Only the coordinates are used to triangulate, and each vertex of the result is
+// given the label of the input point at that position. Where a position appears
+// more than once the first point's label is used.
+// Return: The triangles, or an error describing why the points were rejected
+func TriangulateLabeled[T any](points []LabeledPoint[T]) ([]LabeledTriangle[T], error) {
+	coordinates := make([]Point, len(points))
+	labels := make(map[Point]LabeledPoint[T], len(points))
+	for i, p := range points {
+		coordinates[i] = p.Point
+		if _, ok := labels[p.Point]; !ok {
+			labels[p.Point] = p
+		}
+	}
+
+	triangles, err := Triangulate(coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]LabeledTriangle[T], len(triangles))
+	for i, t := range triangles {
+		result[i] = LabeledTriangle[T]{labels[t.A], labels[t.B], labels[t.C]}
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTriangulateLabeled(t *testing.T) {
+	points := randomPoints(22, 50)
+	labeled := make([]LabeledPoint[string], len(points))
+	for i, p := range points {
+		labeled[i] = LabeledPoint[string]{p, fmt.Sprint("site ", i)}
+	}
+	// A repeated position keeps the first label
+	labeled = append(labeled, LabeledPoint[string]{points[3], "repeat"})
+
+	triangles, err := TriangulateLabeled(labeled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mustTriangulate(t, points)
+	if len(triangles) != len(want) {
+		t.Fatalf("got %d triangles, want %d", len(triangles), len(want))
+	}
+	label := make(map[Point]string, len(points))
+	for i, p := range points {
+		label[p] = fmt.Sprint("site ", i)
+	}
+	for i, triangle := range triangles {
+		if triangle.Triangle() != want[i] {
+			t.Errorf("triangle %d is %v, want %v", i, triangle.Triangle(), want[i])
+		}
+		for _, vertex := range []LabeledPoint[string]{triangle.A, triangle.B, triangle.C} {
+			if vertex.Data != label[vertex.Point] {
+				t.Errorf("vertex %v has label %q, want %q", vertex.Point, vertex.Data, label[vertex.Point])
+			}
+		}
+	}
+
+	collinear := []LabeledPoint[int]{{Point{0, 0}, 1}, {Point{1, 1}, 2}, {Point{2, 2}, 3}}
+	if _, err := TriangulateLabeled(collinear); !errors.Is(err, ErrCollinearPoints) {
+		t.Errorf("TriangulateLabeled(%v) error = %v, want %v", collinear, err, ErrCollinearPoints)
+	}
+}
@@ -0,0 +1,130 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Given an array of points inside a rectangle, spread them out more evenly with
+// Lloyd's algorithm
+// Each iteration moves every point to the centroid of its Voronoi cell, clipped to
+// the rectangle so that the cells of points on the convex hull are bounded. The
+// cells are found exactly, by clipping the rectangle with the bisector between
+// the point and each of its Delaunay neighbors. A point whose cell is empty, as
+// it is for a point outside the rectangle, is left where it is.
+// Return: The moved points, in the same order as points, which is left untouched
+func LloydRelax(points []Point, iterations int, bounds Rect) []Point {
+	relaxed := append([]Point(nil), points...)
+	rectangle := bounds.corners()
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		relaxed = lloydStep(relaxed, rectangle, func(cell []Point) (Point, bool) {
+			if len(cell) >= 3 && PolygonArea(cell) > 0 {
+				return polygonCentroid(cell), true
+			}
+			return Point{}, false
+		})
+	}
+
+	return relaxed
+}
+
+// Given an array of points, a rectangle and a way of finding a center of a cell,
+// move every point to the center of its Voronoi cell clipped to the rectangle
+// Return: The moved points, with a point left where it is when center returns false
+func lloydStep(points []Point, rectangle []Point, center func(cell []Point) (Point, bool)) []Point {
+	super_triangle := ComputeSuperTriangle(points)
+	neighbors := vertexNeighbors(insertPoints(points, super_triangle, Options{}))
+
+	next := make([]Point, len(points))
+	for i, site := range points {
+		next[i] = site
+
+		cell := rectangle
+		for _, other := range neighbors[site] {
+			if super_triangle.ContainsPoint(other) {
+				continue
+			}
+			cell = clipCloserTo(cell, site, other)
+		}
+		if moved, ok := center(cell); ok {
+			next[i] = moved
+		}
+	}
+	return next
+}
+
+// Levels of Subdivide applied to each triangle of a cell when integrating the
+// weight over it in Stipple
+const stipple_subdivisions = 2
+
+// Given a weight over a rectangle, such as the darkness of an image, return n
+// points spread so that their density follows the weight, as for stippling
+// The points start at random positions drawn in proportion to the weight, from a
+// fixed seed so that the result is reproducible, and then each iteration moves
+// every point to the weighted centroid of its Voronoi cell within bounds, as in
+// LloydRelax with each part of the cell counted by its weight. The weight is
+// integrated by splitting each cell into small triangles and sampling it at their
+// centroids. Negative weights count as zero, and a point whose cell has no weight
+// is left where it is.
+// Return: The points, or nil if n is not positive
+func Stipple(weight func(x, y float64) float64, n int, iterations int, bounds Rect) []Point {
+	if n <= 0 {
+		return nil
+	}
+	min, max := bounds.Min, bounds.Max
+	rectangle := bounds.corners()
+	sample := func(p Point) float64 {
+		return math.Max(0, weight(p.X, p.Y))
+	}
+
+	// The largest weight is estimated on a grid, for drawing the starting points
+	// by rejection
+	const grid_side = 64
+	peak := 0.0
+	for i := 0; i < grid_side; i++ {
+		for j := 0; j < grid_side; j++ {
+			x := min.X + (max.X-min.X)*(float64(i)+0.5)/grid_side
+			y := min.Y + (max.Y-min.Y)*(float64(j)+0.5)/grid_side
+			peak = math.Max(peak, sample(Point{x, y}))
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	points := make([]Point, 0, n)
+	for tries := 0; len(points) < n; tries++ {
+		p := Point{min.X + (max.X-min.X)*random.Float64(), min.Y + (max.Y-min.Y)*random.Float64()}
+		// Gives up on the weight after many rejections, so a weight that is nearly
+		// all zero cannot stall
+		if peak <= 0 || tries > 1000*n || random.Float64()*peak < sample(p) {
+			points = append(points, p)
+		}
+	}
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		points = lloydStep(points, rectangle, func(cell []Point) (Point, bool) {
+			if len(cell) < 3 {
+				return Point{}, false
+			}
+			var fan []Triangle
+			for k := 1; k+1 < len(cell); k++ {
+				fan = append(fan, Triangle{cell[0], cell[k], cell[k+1]})
+			}
+
+			var total, x, y float64
+			for _, piece := range Subdivide(fan, stipple_subdivisions) {
+				center := piece.Centroid()
+				mass := sample(center) * piece.Area()
+				total += mass
+				x += mass * center.X
+				y += mass * center.Y
+			}
+			if total <= 0 {
+				return Point{}, false
+			}
+			return Point{x / total, y / total}, true
+		})
+	}
+
+	return points
+}
@@ -0,0 +1,101 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Return: The variance of the distance from each point to its nearest other point
+func nearestDistanceVariance(points []Point) float64 {
+	distances := make([]float64, len(points))
+	for i, p := range points {
+		distances[i] = math.Inf(1)
+		for j, q := range points {
+			if i != j {
+				distances[i] = math.Min(distances[i], p.Distance(q))
+			}
+		}
+	}
+
+	var mean, variance float64
+	for _, d := range distances {
+		mean += d / float64(len(distances))
+	}
+	for _, d := range distances {
+		variance += (d - mean) * (d - mean) / float64(len(distances))
+	}
+	return variance
+}
+
+func TestLloydRelaxEvensOutSpacing(t *testing.T) {
+	points := randomPoints(23, 200)
+	original := append([]Point(nil), points...)
+	bounds := Rect{Point{0, 0}, Point{1, 1}}
+
+	before := nearestDistanceVariance(points)
+	previous := before
+	for _, iterations := range []int{1, 3, 10} {
+		relaxed := LloydRelax(points, iterations, bounds)
+		if len(relaxed) != len(points) {
+			t.Fatalf("LloydRelax() gave %d points, want %d", len(relaxed), len(points))
+		}
+		for _, p := range relaxed {
+			if p.X < bounds.Min.X || p.X > bounds.Max.X || p.Y < bounds.Min.Y || p.Y > bounds.Max.Y {
+				t.Fatalf("after %d iterations %v is outside the rectangle", iterations, p)
+			}
+		}
+		variance := nearestDistanceVariance(relaxed)
+		if variance >= previous {
+			t.Errorf("after %d iterations the variance is %v, up from %v", iterations, variance, previous)
+		}
+		previous = variance
+	}
+	if previous > before/4 {
+		t.Errorf("after 10 iterations the variance is %v, want well under %v", previous, before)
+	}
+
+	if !reflect.DeepEqual(points, original) {
+		t.Error("LloydRelax() modified its input")
+	}
+	if relaxed := LloydRelax(points, 0, bounds); !reflect.DeepEqual(relaxed, points) {
+		t.Error("LloydRelax() with no iterations moved the points")
+	}
+}
+
+func TestStippleFollowsWeight(t *testing.T) {
+	bounds := Rect{Point{0, 0}, Point{1, 1}}
+	corner := func(x, y float64) float64 { return math.Exp(-(x*x + y*y) / 0.1) }
+	uniform := func(x, y float64) float64 { return 1 }
+	inCorner := func(points []Point) int {
+		count := 0
+		for _, p := range points {
+			if p.X < 0.5 && p.Y < 0.5 {
+				count++
+			}
+		}
+		return count
+	}
+
+	weighted := Stipple(corner, 200, 5, bounds)
+	even := Stipple(uniform, 200, 5, bounds)
+	if len(weighted) != 200 || len(even) != 200 {
+		t.Fatalf("got %d and %d points, want 200", len(weighted), len(even))
+	}
+	for _, p := range append(weighted, even...) {
+		if p.X < 0 || p.X > 1 || p.Y < 0 || p.Y > 1 {
+			t.Fatalf("%v is outside the bounds", p)
+		}
+	}
+	// A uniform weight puts about a quarter of the points in the corner
+	if got, uniform := inCorner(weighted), inCorner(even); got < 2*uniform || uniform < 30 || uniform > 70 {
+		t.Errorf("the corner has %d weighted points and %d uniform ones, want at least twice as many", got, uniform)
+	}
+
+	if again := Stipple(corner, 200, 5, bounds); !reflect.DeepEqual(again, weighted) {
+		t.Error("two runs gave different points")
+	}
+	if got := Stipple(corner, 0, 5, bounds); got != nil {
+		t.Errorf("Stipple() of no points = %v, want nil", got)
+	}
+}
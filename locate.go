@@ -0,0 +1,225 @@
+package bowyer_watson
+
+import "math"
+
+// Finds the triangle containing a point
+// Implementations may index the triangles however they like, as long as they
+// report the same triangle that a scan in index order would
+type locator interface {
+	locate(p Point) (int, bool)
+}
+
+// A locator that tests every triangle in turn
+type linearLocator []Triangle
+
+// linearLocator method
+// Return: The index of the first triangle containing p, and true if there is one
+func (triangles linearLocator) locate(p Point) (int, bool) {
+	for i, triangle := range triangles {
+		if triangle.Contains(p) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// A locator that buckets the triangles in a uniform grid by their bounding boxes,
+// so that a query only tests the triangles overlapping its cell
+// It is never changed once built, so it can be queried from several goroutines
+// at once. Triangles whose boxes would cover more than grid_max_cells cells are
+// kept in oversize and tested on every query instead.
+type gridLocator struct {
+	triangles     []Triangle
+	min           Point
+	cell_size     float64
+	columns, rows int
+	// The indices of the triangles overlapping each cell, in increasing order
+	cells    [][]int
+	oversize []int
+}
+
+// Given the triangles of a triangulation, return a gridLocator over them with
+// roughly one cell per triangle
+func newGridLocator(triangles []Triangle) *gridLocator {
+	vertices := make([]Point, 0, 3*len(triangles))
+	for _, triangle := range triangles {
+		vertices = append(vertices, triangle.A, triangle.B, triangle.C)
+	}
+	min, max := BoundingBox(vertices)
+
+	side := math.Ceil(math.Sqrt(float64(len(triangles))))
+	size := math.Max(max.X-min.X, max.Y-min.Y) / side
+	if !(size > 0) || math.IsInf(size, 0) {
+		size = 1
+	}
+
+	l := &gridLocator{
+		triangles: triangles,
+		min:       min,
+		cell_size: size,
+		columns:   int((max.X-min.X)/size) + 1,
+		rows:      int((max.Y-min.Y)/size) + 1,
+	}
+	l.cells = make([][]int, l.columns*l.rows)
+
+	for i, triangle := range triangles {
+		low, high := BoundingBox([]Point{triangle.A, triangle.B, triangle.C})
+		first_column, last_column := l.column(low.X), l.column(high.X)
+		first_row, last_row := l.row(low.Y), l.row(high.Y)
+		if (last_column-first_column+1)*(last_row-first_row+1) > grid_max_cells {
+			l.oversize = append(l.oversize, i)
+			continue
+		}
+		for row := first_row; row <= last_row; row++ {
+			for column := first_column; column <= last_column; column++ {
+				l.cells[row*l.columns+column] = append(l.cells[row*l.columns+column], i)
+			}
+		}
+	}
+	return l
+}
+
+// gridLocator method
+// Return: The column of x, clamped to the grid
+func (l *gridLocator) column(x float64) int {
+	return clampIndex(math.Floor((x-l.min.X)/l.cell_size), l.columns)
+}
+
+// gridLocator method
+// Return: The row of y, clamped to the grid
+func (l *gridLocator) row(y float64) int {
+	return clampIndex(math.Floor((y-l.min.Y)/l.cell_size), l.rows)
+}
+
+// gridLocator method
+// Every triangle containing p overlaps p's cell, so only the triangles of that
+// cell and the oversize ones are tested, merged in increasing order
+// Return: The index of the first triangle containing p, and true if there is one
+func (l *gridLocator) locate(p Point) (int, bool) {
+	if len(l.triangles) == 0 {
+		return -1, false
+	}
+
+	cell := l.cells[l.row(p.Y)*l.columns+l.column(p.X)]
+	oversize := l.oversize
+	for len(cell) > 0 || len(oversize) > 0 {
+		var i int
+		if len(oversize) == 0 || len(cell) > 0 && cell[0] < oversize[0] {
+			i, cell = cell[0], cell[1:]
+		} else {
+			i, oversize = oversize[0], oversize[1:]
+		}
+		if l.triangles[i].Contains(p) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Given the triangles of a triangulation, find the triangle containing a Point
+// Points on an edge shared by two triangles belong to the first of them
+// Return: The index of the triangle, and false (with index -1) if the point is
+// outside the triangulation
+func Locate(triangles []Triangle, p Point) (int, bool) {
+	return linearLocator(triangles).locate(p)
+}
+
+// Given the mesh of a Delaunay triangulation, find the vertex nearest a Point
+// As NearestSiteFrom, starting from the first vertex of the mesh
+// Return: The nearest vertex, and false if the mesh has no vertices
+func NearestSite(mesh *Mesh, p Point) (Point, bool) {
+	if len(mesh.Vertices) == 0 {
+		return Point{}, false
+	}
+	return NearestSiteFrom(mesh, mesh.Vertices[0], p)
+}
+
+// Given the mesh of a Delaunay triangulation, find the vertex nearest a Point by
+// walking from the vertex start
+// The search repeatedly steps to a neighboring vertex that is closer to p. In a
+// Delaunay triangulation a vertex with no closer neighbor is the nearest of all,
+// wherever p is, including outside the convex hull. The walk is short when start
+// is already near p, such as the answer for a previous nearby query, and a start
+// that is not a vertex falls back to the first vertex. If the walk fails to
+// settle, every vertex is scanned instead. The first call indexes the neighbors
+// of every vertex, once even if several goroutines search at the same time, and
+// the index is not updated if Triangles is changed afterwards.
+// Return: The nearest vertex, and false if the mesh has no vertices
+func NearestSiteFrom(mesh *Mesh, start Point, p Point) (Point, bool) {
+	if len(mesh.Vertices) == 0 {
+		return Point{}, false
+	}
+	mesh.neighbors_once.Do(func() {
+		mesh.neighbors = vertexNeighbors(mesh.Triangles)
+	})
+	neighbors := mesh.neighbors
+
+	current := start
+	if _, ok := neighbors[current]; !ok {
+		current = mesh.Vertices[0]
+	}
+	best := p.DistanceSq(current)
+	for steps := 0; steps <= len(neighbors); steps++ {
+		moved := false
+		for _, q := range neighbors[current] {
+			if d := p.DistanceSq(q); d < best {
+				current, best, moved = q, d, true
+			}
+		}
+		if !moved {
+			return current, true
+		}
+	}
+
+	for q := range neighbors {
+		if d := p.DistanceSq(q); d < best {
+			current, best = q, d
+		}
+	}
+	return current, true
+}
+
+// Given a mesh, find the edge nearest a Point, for example to snap a cursor to
+// The distance to an edge is measured to the closest point of the segment, which
+// is the foot of the perpendicular from p when that falls between the endpoints
+// and the nearer endpoint otherwise. Of edges at the same distance the first in
+// mesh.Edges is chosen. Every edge is tested.
+// Return: The nearest edge and its distance from p, or the zero Edge and +Inf if
+// the mesh has no edges
+func NearestEdge(mesh *Mesh, p Point) (Edge, float64) {
+	var nearest Edge
+	best := math.Inf(1)
+	for _, edge := range mesh.Edges {
+		if d := edge.distanceTo(p); d < best {
+			nearest, best = edge, d
+		}
+	}
+	return nearest, best
+}
+
+// Edge method
+// Return: The distance from p to the closest point of the segment
+func (e Edge) distanceTo(p Point) float64 {
+	var length = e.Length()
+	if length == 0 {
+		return p.Distance(e.A)
+	}
+
+	// How far along the edge the foot of the perpendicular is, from 0 at A to 1
+	// at B, kept on the segment
+	along := p.Sub(e.A).Dot(e.B.Sub(e.A)) / (length * length)
+	along = math.Max(0, math.Min(1, along))
+	foot := Point{e.A.X + (e.B.X-e.A.X)*along, e.A.Y + (e.B.Y-e.A.Y)*along}
+	return p.Distance(foot)
+}
+
+// Given the triangles of a triangulation, return the vertices joined to each
+// vertex by an edge
+func vertexNeighbors(triangles []Triangle) map[Point][]Point {
+	neighbors := make(map[Point][]Point)
+	for _, edge := range Edges(triangles) {
+		neighbors[edge.A] = append(neighbors[edge.A], edge.B)
+		neighbors[edge.B] = append(neighbors[edge.B], edge.A)
+	}
+	return neighbors
+}
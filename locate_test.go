@@ -0,0 +1,157 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// Return: The point nearest p, found by checking every point
+func bruteNearest(points []Point, p Point) Point {
+	best := points[0]
+	for _, q := range points {
+		if p.DistanceSq(q) < p.DistanceSq(best) {
+			best = q
+		}
+	}
+	return best
+}
+
+func TestNearestSiteMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for seed := int64(0); seed < 10; seed++ {
+		points := randomPoints(seed, 200)
+		mesh, err := BuildMesh(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		previous := mesh.Vertices[0]
+		for k := 0; k < 200; k++ {
+			// Queries reach outside the convex hull too
+			p := Point{r.Float64()*3 - 1, r.Float64()*3 - 1}
+			want := bruteNearest(points, p)
+			if got, ok := NearestSite(mesh, p); !ok || got != want {
+				t.Fatalf("seed %d: NearestSite(%v) = %v, %v, want %v", seed, p, got, ok, want)
+			}
+			got, ok := NearestSiteFrom(mesh, previous, p)
+			if !ok || got != want {
+				t.Fatalf("seed %d: NearestSiteFrom(%v, %v) = %v, %v, want %v", seed, previous, p, got, ok, want)
+			}
+			previous = got
+		}
+	}
+}
+
+func TestNearestSiteFromNonVertex(t *testing.T) {
+	mesh, err := BuildMesh([]Point{{0, 0}, {4, 0}, {0, 4}, {4, 4}, {2, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := NearestSiteFrom(mesh, Point{100, 100}, Point{2.2, 1.5}); !ok || got != (Point{2, 1}) {
+		t.Errorf("NearestSiteFrom() = %v, %v, want (2, 1), true", got, ok)
+	}
+	if _, ok := NearestSite(NewMesh(nil), Point{}); ok {
+		t.Error("NearestSite of an empty mesh succeeded")
+	}
+}
+
+func TestNearestSiteConcurrent(t *testing.T) {
+	points := randomPoints(137, 300)
+	mesh, err := BuildMesh(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := randomPoints(138, 200)
+
+	// Run with -race, the first searches of every goroutine build the index together
+	var group sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for _, q := range queries {
+				if got, _ := NearestSite(mesh, q); got != bruteNearest(points, q) {
+					t.Errorf("NearestSite(%v) = %v, want %v", q, got, bruteNearest(points, q))
+					return
+				}
+			}
+		}()
+	}
+	group.Wait()
+}
+
+func BenchmarkNearestSite(b *testing.B) {
+	mesh, err := BuildMesh(randomPoints(1, 5000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	queries := randomPoints(2, 1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NearestSite(mesh, queries[i%len(queries)])
+	}
+}
+
+func TestLocate(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{2, 0}, Point{2, 2}},
+		{Point{0, 0}, Point{2, 2}, Point{0, 2}},
+		{Point{2, 0}, Point{4, 1}, Point{2, 2}},
+	}
+	tests := []struct {
+		p     Point
+		index int
+		found bool
+	}{
+		{Point{1.5, 0.5}, 0, true},
+		{Point{0.5, 1.5}, 1, true},
+		{Point{3, 1}, 2, true},
+		{Point{1, 1}, 0, true},
+		{Point{2, 1}, 0, true},
+		{Point{0, 2}, 1, true},
+		{Point{3, 2}, -1, false},
+		{Point{-1, 1}, -1, false},
+		{Point{5, 1}, -1, false},
+	}
+	for _, test := range tests {
+		index, found := Locate(triangles, test.p)
+		if index != test.index || found != test.found {
+			t.Errorf("Locate(%v) = %d, %v, want %d, %v", test.p, index, found, test.index, test.found)
+		}
+		index, found = newGridLocator(triangles).locate(test.p)
+		if index != test.index || found != test.found {
+			t.Errorf("gridLocator.locate(%v) = %d, %v, want %d, %v", test.p, index, found, test.index, test.found)
+		}
+	}
+}
+
+func TestNearestEdge(t *testing.T) {
+	// A square split along its diagonal from 0, 0 to 4, 4
+	a, b, c, d := Point{0, 0}, Point{4, 0}, Point{4, 4}, Point{0, 4}
+	mesh := NewMesh([]Triangle{{a, b, c}, {a, c, d}})
+	tests := []struct {
+		p        Point
+		edge     Edge
+		distance float64
+	}{
+		{Point{2, 0.5}, Edge{a, b}, 0.5},
+		{Point{2, -3}, Edge{a, b}, 3},
+		{Point{3, 2.5}, Edge{c, a}, math.Sqrt(0.125)},
+		{Point{4.5, 1}, Edge{b, c}, 0.5},
+		// Nearest the corner c, where the first of the edges meeting there is chosen
+		{Point{7, 8}, Edge{b, c}, 5},
+		{Point{1, 4}, Edge{c, d}, 0},
+	}
+	for _, test := range tests {
+		edge, distance := NearestEdge(mesh, test.p)
+		if !edge.isEqual(test.edge) || math.Abs(distance-test.distance) > 1e-12 {
+			t.Errorf("NearestEdge(%v) = %v, %v, want %v, %v", test.p, edge, distance, test.edge, test.distance)
+		}
+	}
+
+	if edge, distance := NearestEdge(NewMesh(nil), Point{}); edge != (Edge{}) || !math.IsInf(distance, 1) {
+		t.Errorf("NearestEdge of an empty mesh = %v, %v, want the zero Edge and +Inf", edge, distance)
+	}
+}
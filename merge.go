@@ -0,0 +1,214 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Given the Delaunay triangulations of two sets of points, such as neighboring
+// tiles of a large data set, return the Delaunay triangulation of all the points
+// A triangle of one mesh stays Delaunay unless a vertex of the other mesh is in
+// or on its circumcircle, and a boundary edge of one mesh stays on the boundary
+// unless a vertex of the other is on or beyond it, so only the triangles and
+// boundary edges near the seam are affected. A vertex in both meshes is left out
+// of these tests, as the mesh already has it. The seam, every vertex of the
+// affected triangles and boundary edges, is triangulated again as a whole, and
+// the new triangles with no vertex inside their circumcircle fill the gaps. Where
+// a kept triangle overlaps another kept triangle or a new one, as when the meshes
+// split a square of cocircular points along different diagonals, its vertices
+// join the seam and the seam is triangulated again, until the kept and new
+// triangles fit together. Edges are then flipped as for EnforceDelaunay to repair
+// round-off. Interior triangles far from the seam are kept as they are.
+// The tiles may overlap, share points along their boundary or be apart, and need
+// no buffer around the seam, but each mesh must be the full Delaunay triangulation
+// of its vertices, as from TriangulateDivideConquer. The more of the two meshes
+// overlap, the more of them is triangulated again.
+// Return: The merged mesh, or an error if a vertex is not finite
+func MergeTriangulations(a, b *Mesh) (*Mesh, error) {
+	shared := make(map[Point]bool)
+	in_a := make(map[Point]bool, len(a.Vertices))
+	for _, p := range a.Vertices {
+		in_a[p] = true
+	}
+	for _, p := range b.Vertices {
+		if in_a[p] {
+			shared[p] = true
+		}
+	}
+
+	kept_a, seam_a := seamOf(a, b, shared)
+	kept_b, seam_b := seamOf(b, a, shared)
+
+	seen := make(map[Triangle]bool, len(kept_a)+len(kept_b))
+	var kept []Triangle
+	for _, triangle := range append(kept_a, kept_b...) {
+		if key := triangle.canonical(); !seen[key] {
+			seen[key] = true
+			kept = append(kept, triangle)
+		}
+	}
+	seam := append(seam_a, seam_b...)
+
+	vertices := append([]Point(nil), a.Vertices...)
+	for _, p := range b.Vertices {
+		if !shared[p] {
+			vertices = append(vertices, p)
+		}
+	}
+	sort.Slice(vertices, func(i, j int) bool { return pointLess(vertices[i], vertices[j]) })
+
+	var filled []Triangle
+	for {
+		filled = nil
+		if len(seam) > 0 {
+			triangles, err := TriangulateDivideConquer(seam)
+			if err != nil && !errors.Is(err, ErrTooFewPoints) && !errors.Is(err, ErrCollinearPoints) {
+				return nil, err
+			}
+			// The hull of the seam reaches over kept triangles, and the new
+			// triangles there have a vertex of them in their circumcircle
+			for _, triangle := range triangles {
+				if emptyCircumcircle(vertices, triangle) {
+					filled = append(filled, triangle)
+				}
+			}
+		}
+
+		clashing := overlapping(kept, filled)
+		if len(clashing) == 0 {
+			break
+		}
+		remaining := kept[:0]
+		for i, triangle := range kept {
+			if clashing[i] {
+				seam = append(seam, triangle.A, triangle.B, triangle.C)
+			} else {
+				remaining = append(remaining, triangle)
+			}
+		}
+		kept = remaining
+	}
+
+	// The new triangles include those kept wherever the seam surrounds them
+	final := make(map[Triangle]bool, len(kept))
+	for _, triangle := range kept {
+		final[triangle.canonical()] = true
+	}
+	merged := kept
+	for _, triangle := range filled {
+		if !final[triangle.canonical()] {
+			merged = append(merged, triangle)
+		}
+	}
+	return NewMesh(EnforceDelaunay(merged)), nil
+}
+
+// Given all the vertices, sorted by X and then Y, and a triangle, determine if no
+// vertex is strictly inside its circumcircle
+// Return: True if the triangle is in a Delaunay triangulation of the vertices
+func emptyCircumcircle(sorted []Point, triangle Triangle) bool {
+	triangle = triangle.ToCCW()
+	for _, p := range circumcircleCandidates(sorted, triangle) {
+		if !triangle.ContainsPoint(p) && IncircleDeterminant(triangle.A, triangle.B, triangle.C, p) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Given the triangles kept from two meshes, none of them repeated, and the new
+// triangles of the seam, find the kept triangles that do not fit with the rest
+// Kept triangles are compared with each other and with the new ones, sweeping
+// from left to right as Validate does. A new triangle equal to a kept one fits.
+// Return: The indices of the kept triangles overlapping a different kept or new
+// triangle
+func overlapping(kept, filled []Triangle) map[int]bool {
+	all := make([]Triangle, len(kept)+len(filled))
+	for i, triangle := range kept {
+		all[i] = triangle.ToCCW()
+	}
+	for i, triangle := range filled {
+		all[len(kept)+i] = triangle.ToCCW()
+	}
+	order := make([]int, len(all))
+	low := make([]float64, len(all))
+	high := make([]float64, len(all))
+	for i, triangle := range all {
+		order[i] = i
+		low[i] = math.Min(triangle.A.X, math.Min(triangle.B.X, triangle.C.X))
+		high[i] = math.Max(triangle.A.X, math.Max(triangle.B.X, triangle.C.X))
+	}
+	sort.Slice(order, func(a, b int) bool { return low[order[a]] < low[order[b]] })
+
+	clashing := make(map[int]bool)
+	for a, i := range order {
+		for _, j := range order[a+1:] {
+			if low[j] >= high[i] {
+				break
+			}
+			// Two new triangles never overlap
+			if i >= len(kept) && j >= len(kept) {
+				continue
+			}
+			if all[i].canonical() == all[j].canonical() || !overlap(all[i], all[j]) {
+				continue
+			}
+			if i < len(kept) {
+				clashing[i] = true
+			}
+			if j < len(kept) {
+				clashing[j] = true
+			}
+		}
+	}
+	return clashing
+}
+
+// Given a mesh, another mesh being merged with it and the vertices they share,
+// split its triangles into those that are unaffected by the other's vertices and
+// those that are not
+// Return: The unaffected triangles, and the vertices of the affected triangles and
+// boundary edges
+func seamOf(m, other *Mesh, shared map[Point]bool) ([]Triangle, []Point) {
+	sorted := make([]Point, 0, len(other.Vertices))
+	for _, p := range other.Vertices {
+		// A shared vertex is a vertex of m, so it is not inside any of its
+		// circumcircles
+		if !shared[p] {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return pointLess(sorted[i], sorted[j]) })
+
+	var kept []Triangle
+	var seam []Point
+	for _, triangle := range m.Triangles {
+		triangle = triangle.ToCCW()
+		affected := false
+		for _, p := range circumcircleCandidates(sorted, triangle) {
+			if IncircleDeterminant(triangle.A, triangle.B, triangle.C, p) >= 0 {
+				affected = true
+				break
+			}
+		}
+		if affected {
+			seam = append(seam, triangle.A, triangle.B, triangle.C)
+		} else {
+			kept = append(kept, triangle)
+		}
+	}
+
+	// A point beyond a boundary edge is beyond it wherever the other mesh's hull
+	// is, so only the corners of that hull need testing
+	corners := ConvexHullOfPoints(other.Vertices)
+	for _, edge := range boundaryEdges(m.Triangles) {
+		for _, p := range corners {
+			if p != edge.A && p != edge.B && Orient2D(edge.A, edge.B, p) <= 0 {
+				seam = append(seam, edge.A, edge.B)
+				break
+			}
+		}
+	}
+	return kept, seam
+}
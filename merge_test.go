@@ -0,0 +1,98 @@
+package bowyer_watson
+
+import "testing"
+
+func TestMergeTriangulationsHalves(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right func(p Point) bool
+	}{
+		{"halves", func(p Point) bool { return p.X < 0.5 }, func(p Point) bool { return p.X >= 0.5 }},
+		{"overlapping", func(p Point) bool { return p.X < 0.6 }, func(p Point) bool { return p.X > 0.4 }},
+		{"corner", func(p Point) bool { return p.X < 0.3 && p.Y < 0.3 }, func(p Point) bool { return p.X >= 0.3 || p.Y >= 0.3 }},
+	}
+	for seed := int64(0); seed < 3; seed++ {
+		points := randomPoints(seed, 400)
+		whole := mustTriangulate(t, points)
+		for _, test := range tests {
+			var left, right []Point
+			for _, p := range points {
+				if test.left(p) {
+					left = append(left, p)
+				}
+				if test.right(p) {
+					right = append(right, p)
+				}
+			}
+			a, b := NewMesh(mustTriangulate(t, left)), NewMesh(mustTriangulate(t, right))
+
+			merged, err := MergeTriangulations(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := checkCoversHull(merged.Triangles, points); err != nil {
+				t.Errorf("seed %d, %s: %v", seed, test.name, err)
+			}
+			if len(merged.Triangles) != len(whole) {
+				t.Errorf("seed %d, %s: got %d triangles, want %d", seed, test.name, len(merged.Triangles), len(whole))
+			}
+			if edge, ok := matchesReference(merged.Triangles, true, edgeSet(whole)); !ok {
+				t.Errorf("seed %d, %s: the merged mesh differs from the whole at %v", seed, test.name, edge)
+			}
+		}
+	}
+}
+
+func TestMergeTriangulationsApart(t *testing.T) {
+	left := randomPoints(101, 100)
+	var right []Point
+	for _, p := range randomPoints(102, 100) {
+		right = append(right, Point{p.X + 3, p.Y})
+	}
+	merged, err := MergeTriangulations(NewMesh(mustTriangulate(t, left)), NewMesh(mustTriangulate(t, right)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCoversHull(merged.Triangles, append(left, right...)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMergeTriangulationsLattice(t *testing.T) {
+	// An 8 by 6 lattice, every square of it four cocircular points that either tile
+	// may split along either diagonal
+	grid := func(x0, x1 int) []Point {
+		var points []Point
+		for x := x0; x <= x1; x++ {
+			for y := 0; y < 6; y++ {
+				points = append(points, Point{float64(x), float64(y)})
+			}
+		}
+		return points
+	}
+	tests := []struct {
+		name                string
+		left_to, right_from int
+	}{
+		{"sharing a column", 3, 3},
+		{"sharing two columns", 4, 3},
+		{"overlapping", 5, 2},
+		{"side by side", 3, 4},
+	}
+	whole := grid(0, 7)
+	want := 2*len(whole) - hullPointCount(whole) - 2
+	for _, test := range tests {
+		a := NewMesh(mustTriangulate(t, grid(0, test.left_to)))
+		b := NewMesh(mustTriangulate(t, grid(test.right_from, 7)))
+		merged, err := MergeTriangulations(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkCoversHull(merged.Triangles, whole); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		}
+		if len(merged.Triangles) != want {
+			t.Errorf("%s: got %d triangles, want %d", test.name, len(merged.Triangles), want)
+		}
+	}
+}
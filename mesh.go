@@ -0,0 +1,341 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Given the triangles of a triangulation, return the neighbors of each triangle
+// Entry k of a triangle's neighbors is the index of the triangle sharing its k-th
+// edge, where the edges are AB, BC and CA in that order, or -1 if that edge is on
+// the boundary of the triangulation.
+// Return: One set of neighbor indices per triangle, in the same order as triangles
+func Neighbors(triangles []Triangle) [][3]int {
+	neighbors := make([][3]int, len(triangles))
+
+	// The first triangle seen with each edge, and which of its edges it was
+	type side struct {
+		triangle, edge int
+	}
+	open_edges := make(map[Edge]side, 3*len(triangles))
+
+	for i, triangle := range triangles {
+		neighbors[i] = [3]int{-1, -1, -1}
+		for k, edge := range triangle.edges() {
+			key := edge.normalized()
+			if other, ok := open_edges[key]; ok {
+				neighbors[i][k] = other.triangle
+				neighbors[other.triangle][other.edge] = i
+				delete(open_edges, key)
+			} else {
+				open_edges[key] = side{i, k}
+			}
+		}
+	}
+
+	return neighbors
+}
+
+// Given the triangles of a triangulation, return each distinct edge exactly once
+// Edges are undirected, so AB and BA are the same edge
+// Return: The edges, in the order they are first seen
+func Edges(triangles []Triangle) []Edge {
+	seen := make(map[Edge]bool, 3*len(triangles))
+	var edges []Edge
+
+	for _, triangle := range triangles {
+		for _, edge := range triangle.edges() {
+			key := edge.normalized()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// Given an array of triangles, collect their distinct vertices
+// This indexed form stores each shared vertex once, rather than in every
+// triangle using it, and TrianglesFromIndexed turns it back into triangles
+// Return: The vertices in the order they are first seen, and for each triangle the
+// indices of its A, B and C in that array
+func IndexTriangles(triangles []Triangle) ([]Point, [][3]int) {
+	index := make(map[Point]int, len(triangles))
+	var vertices []Point
+	faces := make([][3]int, len(triangles))
+
+	for i, triangle := range triangles {
+		for k, p := range [3]Point{triangle.A, triangle.B, triangle.C} {
+			j, ok := index[p]
+			if !ok {
+				j = len(vertices)
+				index[p] = j
+				vertices = append(vertices, p)
+			}
+			faces[i][k] = j
+		}
+	}
+
+	return vertices, faces
+}
+
+// Given vertices and, for each triangle, the indices of its A, B and C among
+// them, return the triangles
+// This reverses IndexTriangles
+// Return: The triangles, or an error if an index is outside vertices
+func TrianglesFromIndexed(vertices []Point, faces [][3]int) ([]Triangle, error) {
+	triangles := make([]Triangle, len(faces))
+	for i, face := range faces {
+		for _, index := range face {
+			if index < 0 || index >= len(vertices) {
+				return nil, fmt.Errorf("bowyer_watson: triangle %d refers to vertex %d of %d", i, index, len(vertices))
+			}
+		}
+		triangles[i] = Triangle{vertices[face[0]], vertices[face[1]], vertices[face[2]]}
+	}
+	return triangles, nil
+}
+
+// A triangulation together with the structure derived from it, so that consumers
+// do not each have to recompute it
+// The indexes that queries build on first use are built once even when several
+// goroutines query the mesh at the same time, so the queries are safe to share as
+// long as the fields are not changed meanwhile.
+type Mesh struct {
+	Triangles []Triangle
+	// The distinct vertices of the triangles, in the order they are first seen
+	Vertices []Point
+	// The distinct edges of the triangles, in the order they are first seen
+	Edges []Edge
+	// The neighbors of each triangle, as returned by Neighbors
+	Neighbors [][3]int
+
+	// The triangles using each vertex, built on first use by
+	// AllTrianglesSharingVertex
+	incident      map[Point][]int
+	incident_once sync.Once
+	// The vertices joined to each vertex by an edge, built on first use by
+	// NearestSiteFrom
+	neighbors      map[Point][]Point
+	neighbors_once sync.Once
+}
+
+// Given an array of points, triangulate them and build the Mesh of the result
+// Return: The mesh, or the error from Triangulate if the points cannot be triangulated
+func BuildMesh(points []Point) (*Mesh, error) {
+	triangles, err := Triangulate(points)
+	if err != nil {
+		return nil, err
+	}
+	return NewMesh(triangles), nil
+}
+
+// Given the triangles of a triangulation, return the Mesh built from them
+// The mesh keeps the caller's slice of triangles rather than a copy
+func NewMesh(triangles []Triangle) *Mesh {
+	vertices, _ := IndexTriangles(triangles)
+	return &Mesh{
+		Triangles: triangles,
+		Vertices:  vertices,
+		Edges:     Edges(triangles),
+		Neighbors: Neighbors(triangles),
+	}
+}
+
+// Mesh method
+// Finds the triangle containing a Point, as Locate does
+// Return: The index of the triangle, and false (with index -1) if the point is
+// outside the mesh
+func (m *Mesh) Locate(p Point) (int, bool) {
+	return linearLocator(m.Triangles).locate(p)
+}
+
+// Mesh method
+// Finds every triangle that has p as one of its vertices, for example to walk the
+// fan of triangles around a vertex. The first call indexes every vertex, so later
+// calls only look up p; the index is not updated if Triangles is changed
+// afterwards.
+// Return: The indices of the triangles in increasing order, or nil if p is not a
+// vertex of the mesh
+func (m *Mesh) AllTrianglesSharingVertex(p Point) []int {
+	m.incident_once.Do(func() {
+		m.incident = make(map[Point][]int, len(m.Vertices))
+		for i, triangle := range m.Triangles {
+			m.incident[triangle.A] = append(m.incident[triangle.A], i)
+			// A degenerate triangle may repeat a vertex, but is only listed once
+			if triangle.B != triangle.A {
+				m.incident[triangle.B] = append(m.incident[triangle.B], i)
+			}
+			if triangle.C != triangle.A && triangle.C != triangle.B {
+				m.incident[triangle.C] = append(m.incident[triangle.C], i)
+			}
+		}
+	})
+	return m.incident[p]
+}
+
+// Triangle method
+// Finds the edge shared with another triangle, as when two triangles are
+// neighbors
+// Return: The shared edge, directed as it is in t, and true if exactly two of the
+// triangles' vertices coincide; otherwise false
+func (t Triangle) SharedEdge(other Triangle) (Edge, bool) {
+	shared := 0
+	for _, p := range [3]Point{t.A, t.B, t.C} {
+		if other.ContainsPoint(p) {
+			shared++
+		}
+	}
+	if shared != 2 {
+		return Edge{}, false
+	}
+
+	for _, edge := range t.edges() {
+		for _, other_edge := range other.edges() {
+			if edge.isEqual(other_edge) {
+				return edge, true
+			}
+		}
+	}
+	return Edge{}, false
+}
+
+// Given an array of triangles, perhaps gathered from several sources, merge
+// vertices closer together than a tolerance and return the Mesh of the result
+// Vertices are visited in the order they are first seen in triangles, and each
+// one is moved onto the nearest vertex already kept if that is at most tol away,
+// a distance of exactly tol included; otherwise it is kept. Of two kept vertices
+// at the same distance, the one smaller by X and then Y is used. Triangles left
+// with a repeated vertex are dropped, so triangles that only nearly shared an
+// edge become neighbors. A tol of zero only merges identical vertices.
+// Return: The mesh, or an error if tol is negative or NaN
+func Weld(triangles []Triangle, tol float64) (*Mesh, error) {
+	if !(tol >= 0) {
+		return nil, fmt.Errorf("bowyer_watson: weld tolerance must not be negative, got %v", tol)
+	}
+
+	kept := newNearSet(tol)
+	snap := func(p Point) Point {
+		if q, found := kept.find(p); found {
+			return q
+		}
+		kept.add(p)
+		return p
+	}
+
+	var welded []Triangle
+	for _, triangle := range triangles {
+		t := Triangle{snap(triangle.A), snap(triangle.B), snap(triangle.C)}
+		if t.A == t.B || t.B == t.C || t.C == t.A {
+			continue
+		}
+		welded = append(welded, t)
+	}
+	return NewMesh(welded), nil
+}
+
+// A triangulation prepared for answering many queries, such as from the handlers
+// of a server, which are then cheaper than those of Mesh
+// A grid over the triangles is built once by NewStaticMesh so that Locate only
+// tests the few triangles near the point. The mesh keeps its own copy of the
+// triangles and has no methods that change it, so any number of goroutines may
+// query it at once without locking.
+type StaticMesh struct {
+	triangles []Triangle
+	locator   *gridLocator
+}
+
+// Given the triangles of a triangulation, return a StaticMesh of them
+// The triangles are copied, so changing the caller's slice afterwards does not
+// affect the mesh
+func NewStaticMesh(triangles []Triangle) *StaticMesh {
+	copied := append([]Triangle(nil), triangles...)
+	return &StaticMesh{copied, newGridLocator(copied)}
+}
+
+// StaticMesh method
+// Return: The number of triangles
+func (m *StaticMesh) Len() int {
+	return len(m.triangles)
+}
+
+// StaticMesh method
+// Return: The i-th triangle, in the order given to NewStaticMesh
+func (m *StaticMesh) Triangle(i int) Triangle {
+	return m.triangles[i]
+}
+
+// StaticMesh method
+// Finds the triangle containing a Point, with the same result as Locate
+// Return: The index of the triangle, and false (with index -1) if the point is
+// outside the mesh
+func (m *StaticMesh) Locate(p Point) (int, bool) {
+	return m.locator.locate(p)
+}
+
+// StaticMesh method
+// Estimates the value at a Point as Interpolate does
+// Return: The interpolated value, and false if the point is outside the mesh or a
+// vertex of its triangle has no value
+func (m *StaticMesh) Interpolate(values map[Point]float64, p Point) (float64, bool) {
+	return interpolateWith(m.locator, m.triangles, values, p)
+}
+
+// Given a mesh, order its triangles into strips for rendering, so that each
+// triangle after the first in a strip costs one vertex rather than three
+// Triangle k of a strip is made of its vertices k, k+1 and k+2, and shares an edge
+// with the triangle before it. As GPUs expect, the first triangle of a strip has
+// the winding of the mesh triangle and every second triangle after it is listed
+// with the opposite winding. Strips are grown greedily: starting from the first
+// triangle not yet used, whichever of its edges gives the longest strip is
+// chosen, and the strip follows the neighbor across its last edge until that
+// neighbor is used or missing. Every triangle belongs to exactly one strip.
+// Return: The strips, each a list of at least 3 vertices
+func TriangleStrips(mesh *Mesh) [][]Point {
+	used := make([]bool, len(mesh.Triangles))
+
+	// The strip starting with a, b, c from triangle start, and the triangles in it
+	grow := func(start int, a, b, c Point) ([]Point, []int) {
+		strip, members := []Point{a, b, c}, []int{start}
+		visited := map[int]bool{start: true}
+		for current := start; ; {
+			u, v := strip[len(strip)-2], strip[len(strip)-1]
+			next := -1
+			for k, edge := range mesh.Triangles[current].edges() {
+				if edge.isEqual(Edge{u, v}) {
+					next = mesh.Neighbors[current][k]
+				}
+			}
+			if next < 0 || used[next] || visited[next] {
+				return strip, members
+			}
+			visited[next] = true
+			strip = append(strip, mesh.Triangles[next].opposite(Edge{u, v}))
+			members = append(members, next)
+			current = next
+		}
+	}
+
+	var strips [][]Point
+	for i, triangle := range mesh.Triangles {
+		if used[i] {
+			continue
+		}
+		var best []Point
+		var best_members []int
+		for _, rotation := range [3]Triangle{triangle, {triangle.B, triangle.C, triangle.A}, {triangle.C, triangle.A, triangle.B}} {
+			strip, members := grow(i, rotation.A, rotation.B, rotation.C)
+			if len(members) > len(best_members) {
+				best, best_members = strip, members
+			}
+		}
+		for _, member := range best_members {
+			used[member] = true
+		}
+		strips = append(strips, best)
+	}
+	return strips
+}
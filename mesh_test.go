@@ -0,0 +1,344 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNeighborsSharedDiagonal(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{1, 0}, Point{1, 1}},
+		{Point{0, 0}, Point{1, 1}, Point{0, 1}},
+	}
+	neighbors := Neighbors(triangles)
+
+	// The diagonal is edge CA of the first triangle and edge AB of the second
+	want := [][3]int{{-1, -1, 1}, {0, -1, -1}}
+	for i := range want {
+		if neighbors[i] != want[i] {
+			t.Errorf("Neighbors()[%d] = %v, want %v", i, neighbors[i], want[i])
+		}
+	}
+	for i, other := range []int{1, 0} {
+		count := 0
+		for _, neighbor := range neighbors[i] {
+			if neighbor == other {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("triangle %d lists triangle %d %d times, want once", i, other, count)
+		}
+	}
+}
+
+func TestEdgesSquare(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{1, 0}, Point{1, 1}},
+		{Point{0, 0}, Point{1, 1}, Point{0, 1}},
+	}
+	edges := Edges(triangles)
+	if len(edges) != 5 {
+		t.Fatalf("Edges() = %v, want 5 edges", edges)
+	}
+	want := edgeSet(triangles)
+	for i, edge := range edges {
+		if !want[edge.normalized()] {
+			t.Errorf("Edges() has %v, which is not an edge of the triangles", edge)
+		}
+		for _, other := range edges[:i] {
+			if edge.isEqual(other) {
+				t.Errorf("Edges() has %v twice", edge)
+			}
+		}
+	}
+}
+
+func TestBuildMeshEuler(t *testing.T) {
+	points := append(randomPoints(17, 200), latticePoints(5)...)
+	points = append(points, points[0], points[1])
+	mesh, err := BuildMesh(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With the unbounded face outside the hull, V - E + F = 2
+	vertices, edges, faces := len(mesh.Vertices), len(mesh.Edges), len(mesh.Triangles)+1
+	if vertices-edges+faces != 2 {
+		t.Errorf("V - E + F = %d - %d + %d, want 2", vertices, edges, faces)
+	}
+	if vertices != len(points)-2 {
+		t.Errorf("got %d vertices, want the %d distinct points", vertices, len(points)-2)
+	}
+
+	// Each interior edge is listed by the triangles on both sides of it
+	listed := 0
+	for _, neighbors := range mesh.Neighbors {
+		for _, neighbor := range neighbors {
+			if neighbor >= 0 {
+				listed++
+			}
+		}
+	}
+	boundary := len(ConvexHull(mesh.Triangles))
+	if listed != 2*(edges-boundary) {
+		t.Errorf("neighbors list %d sides, want twice the %d interior edges", listed, edges-boundary)
+	}
+
+	if _, err := BuildMesh(points[:2]); err == nil {
+		t.Error("BuildMesh of 2 points succeeded")
+	}
+}
+
+func TestIndexTrianglesRoundTrip(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1}
+	triangles := []Triangle{{a, b, c}, {a, c, d}}
+	vertices, faces := IndexTriangles(triangles)
+	if want := []Point{a, b, c, d}; !reflect.DeepEqual(vertices, want) {
+		t.Errorf("IndexTriangles() vertices = %v, want %v", vertices, want)
+	}
+	if want := [][3]int{{0, 1, 2}, {0, 2, 3}}; !reflect.DeepEqual(faces, want) {
+		t.Errorf("IndexTriangles() faces = %v, want %v", faces, want)
+	}
+
+	triangles, err := Triangulate(randomPoints(19, 300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vertices, faces = IndexTriangles(triangles)
+	if len(vertices) != 300 {
+		t.Errorf("IndexTriangles() gave %d vertices, want 300", len(vertices))
+	}
+	got, err := TrianglesFromIndexed(vertices, faces)
+	if err != nil || !reflect.DeepEqual(got, triangles) {
+		t.Errorf("TrianglesFromIndexed() = %d triangles, %v, want the original %d", len(got), err, len(triangles))
+	}
+
+	for _, face := range [][3]int{{0, 1, 4}, {-1, 1, 2}} {
+		if _, err := TrianglesFromIndexed([]Point{a, b, c, d}, [][3]int{{0, 1, 2}, face}); err == nil {
+			t.Errorf("TrianglesFromIndexed() accepted the face %v", face)
+		}
+	}
+}
+
+func TestAllTrianglesSharingVertex(t *testing.T) {
+	// A hexagon around its center, which is shared by all six triangles
+	center := Point{0, 0}
+	points := []Point{center}
+	for k := 0; k < 6; k++ {
+		angle := float64(k) * math.Pi / 3
+		points = append(points, Point{math.Cos(angle), math.Sin(angle)})
+	}
+	mesh, err := BuildMesh(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mesh.AllTrianglesSharingVertex(center); !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("AllTrianglesSharingVertex(%v) = %v, want all 6 triangles", center, got)
+	}
+
+	mesh, err = BuildMesh(randomPoints(43, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range mesh.Vertices {
+		var want []int
+		for i, triangle := range mesh.Triangles {
+			if triangle.ContainsPoint(p) {
+				want = append(want, i)
+			}
+		}
+		if got := mesh.AllTrianglesSharingVertex(p); !reflect.DeepEqual(got, want) {
+			t.Errorf("AllTrianglesSharingVertex(%v) = %v, want %v", p, got, want)
+		}
+	}
+	if got := mesh.AllTrianglesSharingVertex(Point{2, 2}); got != nil {
+		t.Errorf("AllTrianglesSharingVertex of a point not in the mesh = %v, want nil", got)
+	}
+}
+
+func TestSharedEdge(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1}
+	tests := []struct {
+		name         string
+		first, other Triangle
+		edge         Edge
+		shared       bool
+	}{
+		{"diagonal", Triangle{a, b, c}, Triangle{a, c, d}, Edge{c, a}, true},
+		{"diagonal reversed", Triangle{a, c, d}, Triangle{a, b, c}, Edge{a, c}, true},
+		{"disjoint", Triangle{a, b, c}, Triangle{Point{5, 5}, Point{6, 5}, Point{5, 6}}, Edge{}, false},
+		{"one vertex", Triangle{a, b, c}, Triangle{c, Point{2, 1}, Point{2, 2}}, Edge{}, false},
+		{"same triangle", Triangle{a, b, c}, Triangle{b, c, a}, Edge{}, false},
+	}
+	for _, test := range tests {
+		edge, shared := test.first.SharedEdge(test.other)
+		if edge != test.edge || shared != test.shared {
+			t.Errorf("%s: SharedEdge() = %v, %v, want %v, %v", test.name, edge, shared, test.edge, test.shared)
+		}
+	}
+}
+
+func TestWeld(t *testing.T) {
+	// The second triangle's copy of the diagonal is a little off
+	a, b, c, d := Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 1}
+	near_a, near_c := Point{1e-7, -1e-7}, Point{1 - 1e-7, 1}
+	triangles := []Triangle{{a, b, c}, {near_a, near_c, d}}
+
+	apart, err := Weld(triangles, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apart.Vertices) != 6 || apart.Neighbors[0] != [3]int{-1, -1, -1} {
+		t.Errorf("below the gap: got %d vertices and neighbors %v, want 6 and none", len(apart.Vertices), apart.Neighbors)
+	}
+
+	welded, err := Weld(triangles, 1e-6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Triangle{{a, b, c}, {a, c, d}}
+	if !reflect.DeepEqual(welded.Triangles, want) || len(welded.Vertices) != 4 {
+		t.Fatalf("Weld() = %v with %d vertices, want %v with 4", welded.Triangles, len(welded.Vertices), want)
+	}
+	if welded.Neighbors[0] != [3]int{-1, -1, 1} || welded.Neighbors[1] != [3]int{0, -1, -1} {
+		t.Errorf("Weld() neighbors = %v, want the triangles adjacent across the diagonal", welded.Neighbors)
+	}
+
+	// Exactly tol apart is merged, and a triangle welded down to an edge is dropped
+	mesh, err := Weld([]Triangle{{a, b, c}, {a, Point{0.5, 0}, Point{1, 0.5}}}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mesh.Triangles) != 1 {
+		t.Errorf("tol 0.5 kept %v, want only the first triangle", mesh.Triangles)
+	}
+
+	for _, tol := range []float64{-1, math.NaN()} {
+		if _, err := Weld(triangles, tol); err == nil {
+			t.Errorf("Weld() accepted tol %v", tol)
+		}
+	}
+}
+
+func TestStaticMeshConcurrentLocate(t *testing.T) {
+	points := randomPoints(83, 500)
+	triangles := mustTriangulate(t, points)
+	mesh := NewStaticMesh(triangles)
+	values := make(map[Point]float64, len(points))
+	for _, p := range points {
+		values[p] = 2*p.X - p.Y
+	}
+
+	queries := append(randomPoints(84, 400), points[:50]...)
+	want := make([]int, len(queries))
+	for i, q := range queries {
+		want[i], _ = Locate(triangles, q)
+	}
+	// Changing the caller's triangles does not affect the mesh
+	triangles[0] = Triangle{}
+
+	var group sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		group.Add(1)
+		go func(offset int) {
+			defer group.Done()
+			for k := range queries {
+				i := (k + offset*50) % len(queries)
+				index, found := mesh.Locate(queries[i])
+				if index != want[i] || found != (want[i] >= 0) {
+					t.Errorf("Locate(%v) = %d, %v, want %d", queries[i], index, found, want[i])
+					return
+				}
+				value, ok := mesh.Interpolate(values, queries[i])
+				if q := queries[i]; ok != found || ok && math.Abs(value-(2*q.X-q.Y)) > 1e-9 {
+					t.Errorf("Interpolate(%v) = %v, %v, want %v", q, value, ok, 2*q.X-q.Y)
+					return
+				}
+			}
+		}(g)
+	}
+	group.Wait()
+}
+
+func TestTriangleStrips(t *testing.T) {
+	// Wound consistently, so that the strips can keep every triangle's winding
+	points := append(randomPoints(107, 300), latticePoints(5)...)
+	triangles, err := TriangulateWithOptions(points, Options{CCW: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mesh := NewMesh(triangles)
+	index := make(map[Triangle]int, len(mesh.Triangles))
+	for i, triangle := range mesh.Triangles {
+		index[triangle.canonical()] = i
+	}
+
+	strips := TriangleStrips(mesh)
+	seen := make([]bool, len(mesh.Triangles))
+	total := 0
+	for _, strip := range strips {
+		if len(strip) < 3 {
+			t.Fatalf("strip %v has fewer than 3 vertices", strip)
+		}
+		var previous Triangle
+		for k := 0; k+2 < len(strip); k++ {
+			triangle := Triangle{strip[k], strip[k+1], strip[k+2]}
+			if k%2 == 1 {
+				triangle.A, triangle.B = triangle.B, triangle.A
+			}
+			i, ok := index[triangle.canonical()]
+			if !ok {
+				t.Fatalf("strip triangle %v is not in the mesh", triangle)
+			}
+			if seen[i] {
+				t.Errorf("triangle %v is in more than one strip", triangle)
+			}
+			seen[i] = true
+			total++
+			if triangle.Orientation() != mesh.Triangles[i].Orientation() {
+				t.Errorf("strip triangle %v is not wound as %v", triangle, mesh.Triangles[i])
+			}
+			if _, shared := triangle.SharedEdge(previous); k > 0 && !shared {
+				t.Errorf("strip triangles %v and %v do not share an edge", previous, triangle)
+			}
+			previous = triangle
+		}
+	}
+	if total != len(mesh.Triangles) {
+		t.Errorf("the strips have %d triangles, the mesh %d", total, len(mesh.Triangles))
+	}
+	if len(strips) >= len(mesh.Triangles)/2 {
+		t.Errorf("got %d strips for %d triangles, want longer strips", len(strips), len(mesh.Triangles))
+	}
+}
+
+func TestMeshConcurrentQueries(t *testing.T) {
+	mesh, err := BuildMesh(randomPoints(131, 300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := NewMesh(mesh.Triangles)
+	want := make([][]int, len(mesh.Vertices))
+	for i, v := range mesh.Vertices {
+		want[i] = fresh.AllTrianglesSharingVertex(v)
+	}
+
+	// Run with -race, the first queries of every goroutine build the index together
+	var group sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for i, v := range mesh.Vertices {
+				if got := mesh.AllTrianglesSharingVertex(v); !reflect.DeepEqual(got, want[i]) {
+					t.Errorf("AllTrianglesSharingVertex(%v) = %v, want %v", v, got, want[i])
+					return
+				}
+			}
+		}()
+	}
+	group.Wait()
+}
@@ -0,0 +1,22 @@
+package bowyer_watson
+
+import (
+	"io"
+)
+
+// Given an array of triangles, write them as a Wavefront OBJ mesh
+// Each distinct vertex is written once as a "v" line with a Z of 0, and each
+// triangle as an "f" line of 1-based vertex indices, as the format requires
+// Return: The first error from writing to w
+func WriteOBJ(w io.Writer, triangles []Triangle) error {
+	vertices, faces := IndexTriangles(triangles)
+
+	ew := &errWriter{w: w}
+	for _, p := range vertices {
+		ew.printf("v %g %g 0\n", p.X, p.Y)
+	}
+	for _, face := range faces {
+		ew.printf("f %d %d %d\n", face[0]+1, face[1]+1, face[2]+1)
+	}
+	return ew.err
+}
@@ -0,0 +1,28 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteOBJ(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteOBJ(&buffer, []Triangle{{Point{0, 0}, Point{1, 0}, Point{0.5, 2}}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "v 0 0 0\nv 1 0 0\nv 0.5 2 0\nf 1 2 3\n"
+	if buffer.String() != want {
+		t.Errorf("WriteOBJ wrote %q, want %q", buffer.String(), want)
+	}
+
+	// Shared vertices are written once
+	buffer.Reset()
+	square := []Triangle{{Point{0, 0}, Point{1, 0}, Point{1, 1}}, {Point{0, 0}, Point{1, 1}, Point{0, 1}}}
+	if err := WriteOBJ(&buffer, square); err != nil {
+		t.Fatal(err)
+	}
+	want = "v 0 0 0\nv 1 0 0\nv 1 1 0\nv 0 1 0\nf 1 2 3\nf 1 3 4\n"
+	if buffer.String() != want {
+		t.Errorf("WriteOBJ wrote %q, want %q", buffer.String(), want)
+	}
+}
@@ -0,0 +1,289 @@
+package bowyer_watson
+
+import (
+	"context"
+	"math"
+	"math/rand"
+)
+
+// Settings for TriangulateWithOptions
+// The zero value gives the same result as Triangulate
+type Options struct {
+	// Tolerance, in the same units as the coordinates, for deciding that a point
+	// is on a circumcircle or that two points are the same.
+	// With a positive Epsilon a point counts as inside a circumcircle when it is
+	// no more than Epsilon outside it, and a point within Epsilon of one already
+	// inserted is skipped as a duplicate. Because it is an absolute distance it
+	// should be chosen relative to the spread of the coordinates: a value that is
+	// negligible for points spread over thousands of units can merge every point
+	// of a set spread over a unit square.
+	// Zero uses exact predicates and exact equality.
+	Epsilon float64
+
+	// Sort the result with SortTriangles
+	Sorted bool
+
+	// Wind every triangle counter-clockwise with Triangle.ToCCW. Combined with
+	// Sorted this is done after sorting, so A is still the smallest vertex.
+	CCW bool
+
+	// Test circumcircles on runtime.NumCPU() goroutines once the triangulation
+	// has at least ParallelThreshold triangles (4096 if zero). The result is the
+	// same as the serial search, as each insertion still waits for every test.
+	// Parallel has no effect when Predicate is set.
+	Parallel          bool
+	ParallelThreshold int
+
+	// Record which cells of a uniform grid over the points each circumcircle
+	// overlaps, so that each insertion only tests the circumcircles near the new
+	// point rather than all of them. The triangles are the same as without the
+	// grid, but come out in a different order unless Sorted is also set.
+	// Parallel has no effect when Grid is set.
+	Grid bool
+
+	// Called after each point is inserted with the number of points handled so
+	// far and the total, for example to draw a progress bar. Duplicate points
+	// count as handled even though they are skipped. Nil means no reporting.
+	Progress func(inserted, total int)
+
+	// Insert the points in an order shuffled with Rand, rather than the order
+	// given. Sorted or clustered input can otherwise create long, thin cavities
+	// that slow each insertion, and a seeded Rand keeps the result reproducible.
+	// The triangles are the same as for the given order, except that where four
+	// or more points are cocircular either triangulation of them may be chosen,
+	// and with a positive Epsilon a different one of two nearby points may be
+	// kept. Nil inserts the points in the order given.
+	Rand *rand.Rand
+
+	// Translate and scale the points into the unit square before triangulating,
+	// which keeps intermediate values small for points far from the origin, and
+	// map the triangles back afterwards. Each output vertex is the input point it
+	// came from exactly, not a round trip through the transform. Epsilon is
+	// scaled with the points. Where rounding maps distinct points to the same
+	// position only the first is kept, as for a duplicate.
+	Normalize bool
+
+	// Decides whether inserting p removes triangle t, in place of the cached
+	// circumcircle test, for example to plug in other exact arithmetic.
+	// Triangle.CircumcircleContains gives the same triangles as nil. The
+	// triangles a point removes must form a cavity that the point can see all of,
+	// including the triangle the point is in, or the result is not a valid
+	// triangulation. Epsilon is then only used to skip duplicate points, Grid has
+	// no effect since the grid only finds triangles whose circumcircles are near
+	// the point, and with Normalize the predicate is given the moved coordinates.
+	// It is only ever called from one goroutine at a time, so Parallel is
+	// ignored. Nil uses the circumcircle test.
+	Predicate func(t Triangle, p Point) bool
+}
+
+// Triangle count above which Options.Parallel takes effect, if not set
+const default_parallel_threshold = 4096
+
+// Given an array of points, return an array of triangles of the triangulation
+// Like Triangulate, with the behaviour adjusted by opts
+// Return: The triangles, or an error describing why the points were rejected
+func TriangulateWithOptions(points []Point, opts Options) ([]Triangle, error) {
+	return triangulate(context.Background(), points, opts)
+}
+
+// Given an array of points, return an array of triangles of the triangulation
+// Like Triangulate, but ctx is checked before each point is inserted so a long
+// triangulation can be abandoned. The work done so far is discarded.
+// Return: The triangles, or ctx.Err() if ctx is cancelled first
+func TriangulateContext(ctx context.Context, points []Point) ([]Triangle, error) {
+	return triangulate(ctx, points, Options{})
+}
+
+// Given an array of points, validate and triangulate them, applying opts
+// Return: The triangles, or the first error from validation or ctx
+func triangulate(ctx context.Context, points []Point, opts Options) ([]Triangle, error) {
+	if err := ValidatePoints(points); err != nil {
+		return nil, err
+	}
+
+	if opts.Rand != nil {
+		points = append([]Point(nil), points...)
+		opts.Rand.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+	}
+
+	var original map[Point]Point
+	if opts.Normalize {
+		points, original, opts.Epsilon = normalizePoints(points, opts.Epsilon)
+	}
+
+	super_triangle := ComputeSuperTriangle(points)
+	triangulation := newTriangulation(super_triangle, opts)
+	if opts.Grid && opts.Predicate == nil {
+		min, max := BoundingBox(points)
+		triangulation.grid = newCircleGrid(min, max, len(points))
+		triangulation.grid.add(triangulation.circles[0], 0, opts.Epsilon)
+	}
+	for i, p := range points {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		triangulation.Insert(p)
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(points))
+		}
+	}
+	triangles := triangulation.Triangles()
+	if opts.Predicate == nil && !triangulation.complete(triangles) {
+		if rebuilt, err := TriangulateDivideConquer(triangulation.inserted.points()); err == nil {
+			triangles = rebuilt
+		}
+	}
+
+	if original != nil {
+		for i, t := range triangles {
+			triangles[i] = Triangle{original[t.A], original[t.B], original[t.C]}
+		}
+	}
+	if opts.Sorted {
+		SortTriangles(triangles)
+	}
+	if opts.CCW {
+		for i := range triangles {
+			triangles[i] = triangles[i].ToCCW()
+		}
+	}
+	return triangles, nil
+}
+
+// Given an array of points and a tolerance, move the points into the unit square
+// The points are translated so their bounding box starts at the origin and scaled
+// by a power of two, so that the larger side of the box is between 1/2 and 1.
+// Return: The moved points, a map from each moved point back to the first input
+// point that became it, and the tolerance scaled to match
+func normalizePoints(points []Point, tolerance float64) ([]Point, map[Point]Point, float64) {
+	min, max := BoundingBox(points)
+	extent := math.Max(max.X-min.X, max.Y-min.Y)
+	scale := 1.0
+	if extent > 0 && !math.IsInf(extent, 1) {
+		_, exponent := math.Frexp(extent)
+		scale = math.Ldexp(1, -exponent)
+	}
+
+	normalized := make([]Point, len(points))
+	original := make(map[Point]Point, len(points))
+	for i, p := range points {
+		normalized[i] = Point{(p.X - min.X) * scale, (p.Y - min.Y) * scale}
+		if _, ok := original[normalized[i]]; !ok {
+			original[normalized[i]] = p
+		}
+	}
+	return normalized, original, tolerance * scale
+}
+
+// A set of points in which points within a tolerance of each other are treated
+// as the same point
+// Points are bucketed into square cells as wide as the tolerance, so only the
+// neighboring cells need to be searched
+type nearSet struct {
+	tolerance float64
+	exact     map[Point]bool
+	cells     map[[2]int64][]Point
+}
+
+// Given a tolerance, return an empty set
+// A tolerance of zero (or less) only treats identical points as the same
+func newNearSet(tolerance float64) *nearSet {
+	if tolerance > 0 {
+		return &nearSet{tolerance: tolerance, cells: make(map[[2]int64][]Point)}
+	}
+	return &nearSet{exact: make(map[Point]bool)}
+}
+
+// nearSet method
+// Adds a point unless the set already has a point within the tolerance of it
+// Return: True if the point was added
+func (s *nearSet) add(p Point) bool {
+	if _, found := s.find(p); found {
+		return false
+	}
+
+	if s.exact != nil {
+		s.exact[p] = true
+		return true
+	}
+	key := [2]int64{int64(math.Floor(p.X / s.tolerance)), int64(math.Floor(p.Y / s.tolerance))}
+	s.cells[key] = append(s.cells[key], p)
+	return true
+}
+
+// nearSet method
+// Finds the point of the set closest to p, if any is within the tolerance of it
+// Points exactly the tolerance away count as within it, and of two points equally
+// close the one that is smaller by X and then Y is chosen
+// Return: The point, and false if there is none
+func (s *nearSet) find(p Point) (Point, bool) {
+	if s.exact != nil {
+		return p, s.exact[p]
+	}
+
+	var best Point
+	found := false
+	cell_x := int64(math.Floor(p.X / s.tolerance))
+	cell_y := int64(math.Floor(p.Y / s.tolerance))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, q := range s.cells[[2]int64{cell_x + dx, cell_y + dy}] {
+				d := p.Distance(q)
+				if d > s.tolerance {
+					continue
+				}
+				if !found || d < p.Distance(best) || d == p.Distance(best) && pointLess(q, best) {
+					best, found = q, true
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+// nearSet method
+// Removes every point, keeping the memory already allocated
+func (s *nearSet) clear() {
+	for p := range s.exact {
+		delete(s.exact, p)
+	}
+	for key := range s.cells {
+		delete(s.cells, key)
+	}
+}
+
+// nearSet method
+// Removes a point that was previously added
+func (s *nearSet) remove(p Point) {
+	if s.exact != nil {
+		delete(s.exact, p)
+		return
+	}
+
+	key := [2]int64{int64(math.Floor(p.X / s.tolerance)), int64(math.Floor(p.Y / s.tolerance))}
+	cell := s.cells[key]
+	for i, q := range cell {
+		if q == p {
+			s.cells[key] = append(cell[:i], cell[i+1:]...)
+			return
+		}
+	}
+}
+
+// nearSet method
+// Return: Every point of the set, in no particular order
+func (s *nearSet) points() []Point {
+	return s.appendPoints(nil)
+}
+
+// nearSet method
+// Return: Every point of the set, in no particular order, appended to points
+func (s *nearSet) appendPoints(points []Point) []Point {
+	for p := range s.exact {
+		points = append(points, p)
+	}
+	for _, cell := range s.cells {
+		points = append(points, cell...)
+	}
+	return points
+}
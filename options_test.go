@@ -0,0 +1,261 @@
+package bowyer_watson
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelMatchesSerial(t *testing.T) {
+	points := randomPoints(3, 3000)
+	serial, err := TriangulateWithOptions(points, Options{Sorted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, err := TriangulateWithOptions(points, Options{Sorted: true, Parallel: true, ParallelThreshold: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Errorf("parallel search gave %d triangles, serial %d", len(parallel), len(serial))
+	}
+}
+
+func TestParallelPredicateNotConcurrent(t *testing.T) {
+	var running, overlapped int32
+	predicate := func(triangle Triangle, p Point) bool {
+		if atomic.AddInt32(&running, 1) > 1 {
+			atomic.StoreInt32(&overlapped, 1)
+		}
+		defer atomic.AddInt32(&running, -1)
+		return triangle.CircumcircleContains(p)
+	}
+
+	points := randomPoints(4, 500)
+	got, err := TriangulateWithOptions(points, Options{Sorted: true, Parallel: true, ParallelThreshold: 1, Predicate: predicate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&overlapped) != 0 {
+		t.Error("Predicate was called concurrently")
+	}
+	want, _ := TriangulateWithOptions(points, Options{Sorted: true})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %d triangles, want %d", len(got), len(want))
+	}
+}
+
+func benchmarkSearch(b *testing.B, n int, opts Options) {
+	points := randomPoints(1, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := TriangulateWithOptions(points, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerialSearch2k(b *testing.B) {
+	benchmarkSearch(b, 2000, Options{})
+}
+
+func BenchmarkParallelSearch2k(b *testing.B) {
+	benchmarkSearch(b, 2000, Options{Parallel: true})
+}
+
+func BenchmarkSerialSearch10k(b *testing.B) {
+	benchmarkSearch(b, 10000, Options{})
+}
+
+func BenchmarkParallelSearch10k(b *testing.B) {
+	benchmarkSearch(b, 10000, Options{Parallel: true})
+}
+
+// A context that is cancelled once Err has been called checks times, so that
+// cancellation happens at a known point of a run
+type cancelAfter struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestTriangulateContextCancelled(t *testing.T) {
+	points := randomPoints(13, 1000)
+
+	ctx := &cancelAfter{context.Background(), 500}
+	triangles, err := TriangulateContext(ctx, points)
+	if !errors.Is(err, context.Canceled) || triangles != nil {
+		t.Errorf("cancelled mid-run: got %d triangles, %v, want nil, %v", len(triangles), err, context.Canceled)
+	}
+	if ctx.checks != 0 {
+		t.Errorf("cancelled after %d insertions, want 500", 500-ctx.checks)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := TriangulateContext(cancelled, points); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled before the run: got %v, want %v", err, context.Canceled)
+	}
+
+	triangles, err = TriangulateContext(context.Background(), points)
+	if want, _ := Triangulate(points); err != nil || !reflect.DeepEqual(triangles, want) {
+		t.Errorf("not cancelled: got %d triangles, %v, want %d", len(triangles), err, len(want))
+	}
+}
+
+func TestProgressCalledForEachPoint(t *testing.T) {
+	// A repeated point is reported even though it is skipped
+	points := append(randomPoints(31, 400), Point{0.5, 0.5}, Point{0.5, 0.5})
+	var calls []int
+	progress := func(inserted, total int) {
+		if total != len(points) {
+			t.Fatalf("Progress called with total %d, want %d", total, len(points))
+		}
+		calls = append(calls, inserted)
+	}
+	got, err := TriangulateWithOptions(points, Options{Progress: progress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != len(points) {
+		t.Fatalf("Progress called %d times, want %d", len(calls), len(points))
+	}
+	for i, inserted := range calls {
+		if inserted != i+1 {
+			t.Fatalf("call %d reported %d inserted, want %d", i, inserted, i+1)
+		}
+	}
+
+	if want, _ := TriangulateWithOptions(points, Options{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("with Progress got %d triangles, without %d", len(got), len(want))
+	}
+}
+
+func TestRandomInsertionOrder(t *testing.T) {
+	points := randomPoints(47, 1000)
+	original := append([]Point(nil), points...)
+
+	first, err := TriangulateWithOptions(points, Options{Rand: rand.New(rand.NewSource(5))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := TriangulateWithOptions(points, Options{Rand: rand.New(rand.NewSource(5))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("two runs with the same seed differ")
+	}
+	if !reflect.DeepEqual(points, original) {
+		t.Error("shuffling modified the caller's points")
+	}
+
+	// Sorted, so that only the triangles and not their order are compared
+	shuffled, err := TriangulateWithOptions(points, Options{Rand: rand.New(rand.NewSource(6)), Sorted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unshuffled, err := TriangulateWithOptions(points, Options{Sorted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(shuffled, unshuffled) {
+		t.Errorf("shuffled gave %d triangles, unshuffled %d", len(shuffled), len(unshuffled))
+	}
+}
+
+func TestNormalizeFarFromOrigin(t *testing.T) {
+	var points []Point
+	for _, p := range randomPoints(53, 300) {
+		points = append(points, Point{1e9 + p.X, 1e9 + p.Y})
+	}
+	points = append(points, Point{1e9, 1e9}, Point{1e9 + 1, 1e9}, Point{1e9 + 1, 1e9 + 1}, Point{1e9, 1e9 + 1})
+
+	triangles, err := TriangulateWithOptions(points, Options{Normalize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCoversHull(triangles, points); err != nil {
+		t.Fatal(err)
+	}
+	// Every vertex is one of the input points exactly, and every point is used
+	given := make(map[Point]bool)
+	for _, p := range points {
+		given[p] = true
+	}
+	vertices, _ := IndexTriangles(triangles)
+	for _, p := range vertices {
+		if !given[p] {
+			t.Errorf("vertex %v is not one of the points", p)
+		}
+	}
+	if len(vertices) != len(points) {
+		t.Errorf("%d of %d points are used", len(vertices), len(points))
+	}
+
+	plain, err := TriangulateWithOptions(points, Options{Sorted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalized, err := TriangulateWithOptions(points, Options{Sorted: true, Normalize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edge, ok := matchesReference(normalized, true, edgeSet(plain)); !ok {
+		t.Errorf("normalized and plain triangulations differ at %v", edge)
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	points := append(randomPoints(89, 300), latticePoints(3)...)
+	want, err := TriangulateWithOptions(points, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	circumcircle := func(triangle Triangle, p Point) bool {
+		calls++
+		return triangle.CircumcircleContains(p)
+	}
+	got, err := TriangulateWithOptions(points, Options{Predicate: circumcircle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls == 0 || !reflect.DeepEqual(got, want) {
+		t.Errorf("CircumcircleContains as the Predicate gave %d triangles in %d calls, want %d", len(got), calls, len(want))
+	}
+
+	// d is just outside the circle through a, b and c, so a predicate with slightly
+	// larger circles takes it as inside and picks the other diagonal
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{0, 2}, Point{2, 2 + 1e-6}
+	inflated := func(triangle Triangle, p Point) bool {
+		return p.Distance(triangle.Circumcenter()) < triangle.CircumRadius()+1e-5
+	}
+	for _, test := range []struct {
+		predicate func(Triangle, Point) bool
+		diagonal  Edge
+	}{
+		{nil, Edge{b, c}},
+		{circumcircle, Edge{b, c}},
+		{inflated, Edge{a, d}},
+	} {
+		triangles, err := TriangulateWithOptions([]Point{a, b, c, d}, Options{Predicate: test.predicate})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(triangles) != 2 || !edgeSet(triangles)[test.diagonal.normalized()] {
+			t.Errorf("got %v, want the diagonal %v", triangles, test.diagonal)
+		}
+	}
+}
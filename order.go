@@ -0,0 +1,83 @@
+package bowyer_watson
+
+import (
+	"sort"
+)
+
+// Determine if Point p comes before Point q, ordering by X and then by Y
+func pointLess(p, q Point) bool {
+	return p.X < q.X || p.X == q.X && p.Y < q.Y
+}
+
+// Triangle method
+// Orders the vertices so that A < B < C, comparing by X and then by Y
+// Return: The triangle with its vertices in canonical order
+func (t Triangle) canonical() Triangle {
+	if pointLess(t.B, t.A) {
+		t.A, t.B = t.B, t.A
+	}
+	if pointLess(t.C, t.B) {
+		t.B, t.C = t.C, t.B
+	}
+	if pointLess(t.B, t.A) {
+		t.A, t.B = t.B, t.A
+	}
+	return t
+}
+
+// Given an array of triangles, put them into a canonical order, in place
+// Each triangle's vertices are ordered by X and then by Y, and the triangles are
+// then ordered by comparing A, then B, then C. Two triangulations with the same
+// triangles therefore always come out identical, whatever order they were built in.
+func SortTriangles(triangles []Triangle) {
+	for i := range triangles {
+		triangles[i] = triangles[i].canonical()
+	}
+
+	sort.Slice(triangles, func(i, j int) bool {
+		a, b := triangles[i], triangles[j]
+		if a.A != b.A {
+			return pointLess(a.A, b.A)
+		}
+		if a.B != b.B {
+			return pointLess(a.B, b.B)
+		}
+		return pointLess(a.C, b.C)
+	})
+}
+
+// The winding of a triangle's vertices
+type Orientation int
+
+const (
+	// A, B, C turn clockwise
+	CW Orientation = iota - 1
+	// A, B, C are on a single line
+	Collinear
+	// A, B, C turn counter-clockwise
+	CCW
+)
+
+// Triangle method
+// Determines which way the vertices A, B, C turn, using an exact predicate
+// Return: CW, CCW, or Collinear
+func (t Triangle) Orientation() Orientation {
+	var det = Orient2D(t.A, t.B, t.C)
+	switch {
+	case det > 0:
+		return CCW
+	case det < 0:
+		return CW
+	}
+	return Collinear
+}
+
+// Triangle method
+// Swaps B and C if the vertices are clockwise; other triangles are unchanged
+// Return: The triangle with counter-clockwise winding
+func (t Triangle) ToCCW() Triangle {
+	if t.Orientation() == CW {
+		t.B, t.C = t.C, t.B
+	}
+	return t
+}
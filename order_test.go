@@ -0,0 +1,95 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestSortedOutputIsIdentical(t *testing.T) {
+	points := append(randomPoints(10, 300), latticePoints(5)...)
+
+	// Without cocircular points the triangulation is unique, so the points in
+	// another order give the same bytes too
+	general := randomPoints(10, 300)
+	shuffled := append([]Point(nil), general...)
+	rand.New(rand.NewSource(11)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	tests := []struct {
+		first, second []Point
+	}{
+		{points, points},
+		{general, shuffled},
+	}
+	for _, test := range tests {
+		var output [2][]byte
+		for i, input := range [][]Point{test.first, test.second} {
+			triangles, err := TriangulateWithOptions(input, Options{Sorted: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output[i], err = json.Marshal(triangles); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !bytes.Equal(output[0], output[1]) {
+			t.Errorf("two sorted triangulations of %d points differ", len(test.first))
+		}
+	}
+}
+
+func TestSortTriangles(t *testing.T) {
+	triangles := []Triangle{
+		{Point{2, 2}, Point{1, 0}, Point{0, 1}},
+		{Point{1, 0}, Point{0, 0}, Point{0, 1}},
+		{Point{1, 0}, Point{2, 2}, Point{2, 0}},
+	}
+	SortTriangles(triangles)
+	want := []Triangle{
+		{Point{0, 0}, Point{0, 1}, Point{1, 0}},
+		{Point{0, 1}, Point{1, 0}, Point{2, 2}},
+		{Point{1, 0}, Point{2, 0}, Point{2, 2}},
+	}
+	for i := range want {
+		if triangles[i] != want[i] {
+			t.Errorf("SortTriangles()[%d] = %v, want %v", i, triangles[i], want[i])
+		}
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	tests := []struct {
+		triangle Triangle
+		want     Orientation
+	}{
+		{Triangle{Point{0, 0}, Point{1, 0}, Point{0, 1}}, CCW},
+		{Triangle{Point{0, 0}, Point{0, 1}, Point{1, 0}}, CW},
+		{Triangle{Point{0, 0}, Point{1, 1}, Point{2, 2}}, Collinear},
+		{Triangle{Point{0, 0}, Point{0, 0}, Point{1, 0}}, Collinear},
+	}
+	for _, test := range tests {
+		if got := test.triangle.Orientation(); got != test.want {
+			t.Errorf("%v.Orientation() = %v, want %v", test.triangle, got, test.want)
+		}
+		// ToCCW only ever reorders the vertices
+		ccw := test.triangle.ToCCW()
+		if !ccw.Equal(test.triangle) || test.want != Collinear && ccw.Orientation() != CCW {
+			t.Errorf("%v.ToCCW() = %v", test.triangle, ccw)
+		}
+	}
+}
+
+func TestCCWOutput(t *testing.T) {
+	points := append(randomPoints(12, 500), latticePoints(4)...)
+	for _, opts := range []Options{{CCW: true}, {CCW: true, Sorted: true}} {
+		triangles, err := TriangulateWithOptions(points, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, triangle := range triangles {
+			if triangle.SignedArea() <= 0 {
+				t.Fatalf("%+v: %v has signed area %v", opts, triangle, triangle.SignedArea())
+			}
+		}
+	}
+}
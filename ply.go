@@ -0,0 +1,45 @@
+package bowyer_watson
+
+import (
+	"image/color"
+	"io"
+)
+
+// Given an array of triangles, write them as an ASCII PLY mesh
+// Each distinct vertex is written once with a z of 0, and each triangle as a
+// face listing the 0-based indices of its vertices
+// Return: The first error from writing to w
+func WritePLY(w io.Writer, triangles []Triangle) error {
+	return WritePLYWithColors(w, triangles, nil)
+}
+
+// Given an array of triangles and a color for each vertex, write them as an ASCII
+// PLY mesh
+// As WritePLY, with red, green and blue properties of 0 to 255 added to every
+// vertex, taken from colors(p) without its alpha. A nil colors writes no color
+// properties.
+// Return: The first error from writing to w
+func WritePLYWithColors(w io.Writer, triangles []Triangle, colors func(p Point) color.Color) error {
+	vertices, faces := IndexTriangles(triangles)
+
+	ew := &errWriter{w: w}
+	ew.printf("ply\nformat ascii 1.0\n")
+	ew.printf("element vertex %d\nproperty double x\nproperty double y\nproperty double z\n", len(vertices))
+	if colors != nil {
+		ew.printf("property uchar red\nproperty uchar green\nproperty uchar blue\n")
+	}
+	ew.printf("element face %d\nproperty list uchar int vertex_indices\nend_header\n", len(faces))
+
+	for _, p := range vertices {
+		if colors == nil {
+			ew.printf("%g %g 0\n", p.X, p.Y)
+			continue
+		}
+		c := color.NRGBAModel.Convert(colors(p)).(color.NRGBA)
+		ew.printf("%g %g 0 %d %d %d\n", p.X, p.Y, c.R, c.G, c.B)
+	}
+	for _, face := range faces {
+		ew.printf("3 %d %d %d\n", face[0], face[1], face[2])
+	}
+	return ew.err
+}
@@ -0,0 +1,130 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"bytes"
+	"image/color"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// An element declared in a PLY header, with the values of each of its rows
+type plyElement struct {
+	name       string
+	count      int
+	properties []string
+	rows       [][]float64
+}
+
+// Given an ASCII PLY file, read it as a basic PLY parser would: the elements
+// declared in the header, in order, then that many rows of each
+// Return: The elements with their rows
+func readPLY(t *testing.T, data []byte) []*plyElement {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() || scanner.Text() != "ply" || !scanner.Scan() || scanner.Text() != "format ascii 1.0" {
+		t.Fatal("the file does not start with the ASCII PLY magic")
+	}
+
+	var elements []*plyElement
+	for {
+		if !scanner.Scan() {
+			t.Fatal("the header has no end_header")
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 1 && fields[0] == "end_header" {
+			break
+		}
+		switch {
+		case len(fields) == 3 && fields[0] == "element":
+			count, err := strconv.Atoi(fields[2])
+			if err != nil {
+				t.Fatalf("element %s has count %q", fields[1], fields[2])
+			}
+			elements = append(elements, &plyElement{name: fields[1], count: count})
+		case len(fields) >= 3 && fields[0] == "property" && len(elements) > 0:
+			element := elements[len(elements)-1]
+			element.properties = append(element.properties, fields[len(fields)-1])
+		default:
+			t.Fatalf("unexpected header line %q", scanner.Text())
+		}
+	}
+
+	for _, element := range elements {
+		for i := 0; i < element.count; i++ {
+			if !scanner.Scan() {
+				t.Fatalf("element %s has %d rows, the header declares %d", element.name, i, element.count)
+			}
+			var row []float64
+			for _, field := range strings.Fields(scanner.Text()) {
+				value, err := strconv.ParseFloat(field, 64)
+				if err != nil {
+					t.Fatalf("element %s row %d: %v", element.name, i, err)
+				}
+				row = append(row, value)
+			}
+			element.rows = append(element.rows, row)
+		}
+	}
+	if scanner.Scan() {
+		t.Fatalf("%q after the last element", scanner.Text())
+	}
+	return elements
+}
+
+func TestWritePLY(t *testing.T) {
+	triangles, err := Triangulate(randomPoints(97, 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := func(p Point) color.Color { return color.RGBA{255, uint8(100 * p.X), 0, 255} }
+
+	for _, colors := range []func(Point) color.Color{nil, red} {
+		var buffer bytes.Buffer
+		if err := WritePLYWithColors(&buffer, triangles, colors); err != nil {
+			t.Fatal(err)
+		}
+		elements := readPLY(t, buffer.Bytes())
+		if len(elements) != 2 || elements[0].name != "vertex" || elements[1].name != "face" {
+			t.Fatalf("got %d elements, want vertex and face", len(elements))
+		}
+		vertices, faces := elements[0], elements[1]
+		if vertices.count != 30 || faces.count != len(triangles) {
+			t.Errorf("the header declares %d vertices and %d faces, want 30 and %d", vertices.count, faces.count, len(triangles))
+		}
+
+		width := 3
+		if colors != nil {
+			width = 6
+		}
+		if len(vertices.properties) != width {
+			t.Errorf("vertices have properties %v, want %d", vertices.properties, width)
+		}
+		for i, face := range faces.rows {
+			if len(face) != 4 || face[0] != 3 {
+				t.Fatalf("face %d is %v, want 3 indices", i, face)
+			}
+			var corners [3]Point
+			for k := range corners {
+				row := vertices.rows[int(face[k+1])]
+				if len(row) != width || row[2] != 0 {
+					t.Fatalf("vertex %v has the wrong fields", row)
+				}
+				corners[k] = Point{row[0], row[1]}
+				if colors != nil && (row[3] != 255 || row[4] != float64(uint8(100*row[0])) || row[5] != 0) {
+					t.Errorf("vertex %v has the wrong color", row)
+				}
+			}
+			if got := (Triangle{corners[0], corners[1], corners[2]}); got != triangles[i] {
+				t.Errorf("face %d is %v, want %v", i, got, triangles[i])
+			}
+		}
+	}
+
+	var plain, uncolored bytes.Buffer
+	WritePLY(&plain, triangles)
+	WritePLYWithColors(&uncolored, triangles, nil)
+	if plain.String() != uncolored.String() {
+		t.Error("WritePLY differs from WritePLYWithColors with no colors")
+	}
+}
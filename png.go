@@ -0,0 +1,129 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Controls the appearance of the image written by RenderPNG
+// The zero value draws black outlines on white with no fill
+type PNGOptions struct {
+	// Color behind the triangles, white if nil
+	Background color.Color
+	// Color of the triangle outlines, black if nil. A color with zero alpha
+	// draws no outlines.
+	Stroke color.Color
+	// Color inside the i-th triangle, or nil to leave it unfilled. Nil fills no
+	// triangles.
+	Fill func(i int, t Triangle) color.Color
+	// Fraction of the smaller side of the image left empty around the drawing,
+	// 0.05 if zero
+	Padding float64
+}
+
+// Given an array of triangles, render them as a PNG image width by height pixels
+// The vertices are scaled uniformly to fit the image, centered, with the Y axis
+// pointing up as it does for Points. A pixel is filled when its center is inside
+// or on a triangle, and outlines are drawn one pixel wide over the fills.
+// A nil options uses the defaults described on PNGOptions
+// Return: An error if the size is not positive, or the first error from writing to w
+func RenderPNG(w io.Writer, triangles []Triangle, width, height int, options *PNGOptions) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("bowyer_watson: image size must be positive, got %dx%d", width, height)
+	}
+
+	var opts PNGOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.Background == nil {
+		opts.Background = color.White
+	}
+	if opts.Stroke == nil {
+		opts.Stroke = color.Black
+	}
+	if opts.Padding == 0 {
+		opts.Padding = svg_padding
+	}
+
+	vertices := make([]Point, 0, 3*len(triangles))
+	for _, triangle := range triangles {
+		vertices = append(vertices, triangle.A, triangle.B, triangle.C)
+	}
+	min, max := BoundingBox(vertices)
+
+	padding := opts.Padding * math.Min(float64(width), float64(height))
+	scale := math.Min((float64(width)-2*padding)/(max.X-min.X), (float64(height)-2*padding)/(max.Y-min.Y))
+	if math.IsInf(scale, 0) || math.IsNaN(scale) || scale <= 0 {
+		scale = 1
+	}
+	offset_x := (float64(width) - scale*(max.X-min.X)) / 2
+	offset_y := (float64(height) - scale*(max.Y-min.Y)) / 2
+	toPixels := func(p Point) Point {
+		return Point{offset_x + (p.X-min.X)*scale, float64(height) - offset_y - (p.Y-min.Y)*scale}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, opts.Background)
+		}
+	}
+
+	if opts.Fill != nil {
+		for i, triangle := range triangles {
+			if fill := opts.Fill(i, triangle); fill != nil {
+				fillTriangle(img, Triangle{toPixels(triangle.A), toPixels(triangle.B), toPixels(triangle.C)}, fill)
+			}
+		}
+	}
+
+	if _, _, _, alpha := opts.Stroke.RGBA(); alpha != 0 {
+		for _, triangle := range triangles {
+			for _, edge := range triangle.edges() {
+				drawLine(img, toPixels(edge.A), toPixels(edge.B), opts.Stroke)
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// Given an image and a triangle in pixel coordinates, set every pixel whose
+// center is inside or on the triangle
+func fillTriangle(img *image.RGBA, t Triangle, c color.Color) {
+	bounds := img.Bounds()
+	first_x := int(math.Max(math.Floor(math.Min(t.A.X, math.Min(t.B.X, t.C.X))), float64(bounds.Min.X)))
+	last_x := int(math.Min(math.Ceil(math.Max(t.A.X, math.Max(t.B.X, t.C.X))), float64(bounds.Max.X-1)))
+	first_y := int(math.Max(math.Floor(math.Min(t.A.Y, math.Min(t.B.Y, t.C.Y))), float64(bounds.Min.Y)))
+	last_y := int(math.Min(math.Ceil(math.Max(t.A.Y, math.Max(t.B.Y, t.C.Y))), float64(bounds.Max.Y-1)))
+
+	for y := first_y; y <= last_y; y++ {
+		for x := first_x; x <= last_x; x++ {
+			if t.Contains(Point{float64(x) + 0.5, float64(y) + 0.5}) {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// Given an image and the ends of a line in pixel coordinates, set the pixels
+// along the line, one per step along its longer axis
+func drawLine(img *image.RGBA, from, to Point, c color.Color) {
+	steps := int(math.Ceil(math.Max(math.Abs(to.X-from.X), math.Abs(to.Y-from.Y))))
+	for i := 0; i <= steps; i++ {
+		along := 0.0
+		if steps > 0 {
+			along = float64(i) / float64(steps)
+		}
+		x := int(math.Floor(from.X + (to.X-from.X)*along))
+		y := int(math.Floor(from.Y + (to.Y-from.Y)*along))
+		if image.Pt(x, y).In(img.Bounds()) {
+			img.Set(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRenderPNG(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{1, 0}, Point{1, 1}},
+		{Point{0, 0}, Point{1, 1}, Point{0, 1}},
+	}
+	red := color.RGBA{255, 0, 0, 255}
+	fill := func(i int, t Triangle) color.Color {
+		if i == 0 {
+			return red
+		}
+		return nil
+	}
+
+	for _, options := range []*PNGOptions{nil, {Fill: fill}} {
+		var buffer bytes.Buffer
+		if err := RenderPNG(&buffer, triangles, 120, 80, options); err != nil {
+			t.Fatal(err)
+		}
+		image, err := png.Decode(&buffer)
+		if err != nil {
+			t.Fatalf("RenderPNG wrote an invalid PNG: %v", err)
+		}
+		if size := image.Bounds().Size(); size.X != 120 || size.Y != 80 {
+			t.Fatalf("got a %dx%d image, want 120x80", size.X, size.Y)
+		}
+
+		// The unit square is fitted to the middle 72 pixels of the height, with
+		// the first triangle below and right of the diagonal
+		if got := color.RGBAModel.Convert(image.At(2, 2)); got != color.RGBAModel.Convert(color.White) {
+			t.Errorf("the corner is %v, want the white background", got)
+		}
+		want := color.RGBAModel.Convert(color.White)
+		if options != nil {
+			want = red
+		}
+		if got := color.RGBAModel.Convert(image.At(80, 60)); got != want {
+			t.Errorf("inside the first triangle is %v, want %v", got, want)
+		}
+		if got := color.RGBAModel.Convert(image.At(40, 20)); got != color.RGBAModel.Convert(color.White) {
+			t.Errorf("inside the second triangle is %v, want it unfilled", got)
+		}
+	}
+
+	for _, size := range [][2]int{{0, 10}, {10, -1}} {
+		if err := RenderPNG(&bytes.Buffer{}, triangles, size[0], size[1], nil); err == nil {
+			t.Errorf("RenderPNG() accepted the size %dx%d", size[0], size[1])
+		}
+	}
+}
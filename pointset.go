@@ -0,0 +1,57 @@
+package bowyer_watson
+
+// A collection of distinct points that tracks its bounding box as it grows, for
+// gathering input to Triangulate or ComputeSuperTriangle
+// The zero value is an empty set ready to use.
+type PointSet struct {
+	points   []Point
+	seen     map[Point]bool
+	min, max Point
+}
+
+// PointSet method
+// Adds a point unless an identical point is already in the set
+// Return: True if the point was added, false if it was a duplicate
+func (s *PointSet) Add(p Point) bool {
+	if s.seen == nil {
+		s.seen = make(map[Point]bool)
+	}
+	if s.seen[p] {
+		return false
+	}
+	s.seen[p] = true
+
+	first := len(s.points) == 0
+	if first || p.X < s.min.X {
+		s.min.X = p.X
+	}
+	if first || p.Y < s.min.Y {
+		s.min.Y = p.Y
+	}
+	if first || p.X > s.max.X {
+		s.max.X = p.X
+	}
+	if first || p.Y > s.max.Y {
+		s.max.Y = p.Y
+	}
+	s.points = append(s.points, p)
+	return true
+}
+
+// PointSet method
+// Return: The number of points in the set
+func (s *PointSet) Len() int {
+	return len(s.points)
+}
+
+// PointSet method
+// Return: The points in the order they were added, in a new slice
+func (s *PointSet) Points() []Point {
+	return append([]Point(nil), s.points...)
+}
+
+// PointSet method
+// Return: The corners of the bounding box of the points, as BoundingBox gives
+func (s *PointSet) Bounds() (min, max Point) {
+	return s.min, s.max
+}
@@ -0,0 +1,61 @@
+package bowyer_watson
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPointSet(t *testing.T) {
+	var set PointSet
+	if min, max := set.Bounds(); set.Len() != 0 || min != (Point{}) || max != (Point{}) {
+		t.Errorf("empty set has %d points, bounds %v %v", set.Len(), min, max)
+	}
+
+	tests := []struct {
+		p        Point
+		added    bool
+		min, max Point
+	}{
+		{Point{2, 3}, true, Point{2, 3}, Point{2, 3}},
+		{Point{2, 3}, false, Point{2, 3}, Point{2, 3}},
+		{Point{-1, 5}, true, Point{-1, 3}, Point{2, 5}},
+		{Point{4, -2}, true, Point{-1, -2}, Point{4, 5}},
+		{Point{-1, 5}, false, Point{-1, -2}, Point{4, 5}},
+		{Point{0, 0}, true, Point{-1, -2}, Point{4, 5}},
+		{Point{0, 0}, false, Point{-1, -2}, Point{4, 5}},
+	}
+	size := 0
+	for _, test := range tests {
+		if got := set.Add(test.p); got != test.added {
+			t.Errorf("Add(%v) = %v, want %v", test.p, got, test.added)
+		}
+		if test.added {
+			size++
+		}
+		if set.Len() != size {
+			t.Errorf("after Add(%v) the set has %d points, want %d", test.p, set.Len(), size)
+		}
+		if min, max := set.Bounds(); min != test.min || max != test.max {
+			t.Errorf("after Add(%v) Bounds() = %v, %v, want %v, %v", test.p, min, max, test.min, test.max)
+		}
+	}
+
+	want := []Point{{2, 3}, {-1, 5}, {4, -2}, {0, 0}}
+	points := set.Points()
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("Points() = %v, want %v", points, want)
+	}
+	points[0] = Point{9, 9}
+	if set.Points()[0] != want[0] {
+		t.Error("changing the result of Points() changed the set")
+	}
+	min, max := set.Bounds()
+	if want_min, want_max := BoundingBox(want); min != want_min || max != want_max {
+		t.Errorf("Bounds() = %v, %v, BoundingBox gives %v, %v", min, max, want_min, want_max)
+	}
+
+	triangles, err := Triangulate(set.Points())
+	if err != nil || len(triangles) != 2 {
+		t.Errorf("Triangulate(%v) = %v, %v, want 2 triangles", set.Points(), triangles, err)
+	}
+}
@@ -0,0 +1,289 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/big"
+)
+
+// Geometric predicates evaluated in floating point, with a fall back to exact
+// rational arithmetic whenever the floating point result is too close to zero
+// for its sign to be trusted. The error bounds are those derived by Shewchuk in
+// "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric
+// Predicates", so the sign of each result is always correct.
+
+// Half of the distance from 1 to the next float64
+const epsilon = 1.0 / (1 << 53)
+
+var ccw_error_bound = (3 + 16*epsilon) * epsilon
+var incircle_error_bound = (10 + 96*epsilon) * epsilon
+var orient3d_error_bound = (7 + 56*epsilon) * epsilon
+var insphere_error_bound = (16 + 224*epsilon) * epsilon
+
+// Given three points, determine the orientation of a, b, c
+// The sign is always correct, so a point exactly on a line is never reported as
+// being to one side of it
+// Return: Positive when counter-clockwise, negative when clockwise and zero when
+// collinear. The magnitude is approximately twice the area of the triangle. NaN
+// if a coordinate is not finite.
+func Orient2D(a, b, c Point) float64 {
+	var det_left = (a.X - c.X) * (b.Y - c.Y)
+	var det_right = (a.Y - c.Y) * (b.X - c.X)
+	var det = det_left - det_right
+
+	var bound = ccw_error_bound * (math.Abs(det_left) + math.Abs(det_right))
+	if det > bound || -det > bound {
+		return det
+	}
+	// Two equal points are exactly collinear with any third, as in the empty
+	// triangle a Triangulator is reset with
+	if a == b || a == c || b == c {
+		return 0
+	}
+	if !finite(a.X, a.Y, b.X, b.Y, c.X, c.Y) {
+		return math.NaN()
+	}
+	return orient2dExact(a, b, c)
+}
+
+// Orient2D using exact rational arithmetic
+func orient2dExact(a, b, c Point) float64 {
+	ax, ay, bx, by, cx, cy := rat(a.X), rat(a.Y), rat(b.X), rat(b.Y), rat(c.X), rat(c.Y)
+
+	left := mul(sub(ax, cx), sub(by, cy))
+	right := mul(sub(ay, cy), sub(bx, cx))
+	return ratFloat64(sub(left, right))
+}
+
+// Given four points, determine where d lies relative to the circle through a, b and c
+// This is the determinant behind CircumcircleContains, for building other tests on.
+// Its sign is always correct, but when it is recomputed exactly its magnitude is
+// rounded to a float64, so only the sign should be relied on.
+// Return: Positive when d is inside the circle and a, b, c are counter-clockwise,
+// negative when d is outside, and zero when the four points are cocircular.
+// The sign is reversed when a, b, c are clockwise. NaN if a coordinate is not
+// finite.
+func IncircleDeterminant(a, b, c, d Point) float64 {
+	var adx, ady = a.X - d.X, a.Y - d.Y
+	var bdx, bdy = b.X - d.X, b.Y - d.Y
+	var cdx, cdy = c.X - d.X, c.Y - d.Y
+
+	var bdx_cdy, cdx_bdy = bdx * cdy, cdx * bdy
+	var cdx_ady, adx_cdy = cdx * ady, adx * cdy
+	var adx_bdy, bdx_ady = adx * bdy, bdx * ady
+
+	var a_lift = adx*adx + ady*ady
+	var b_lift = bdx*bdx + bdy*bdy
+	var c_lift = cdx*cdx + cdy*cdy
+
+	var det = a_lift*(bdx_cdy-cdx_bdy) + b_lift*(cdx_ady-adx_cdy) + c_lift*(adx_bdy-bdx_ady)
+
+	var permanent = (math.Abs(bdx_cdy)+math.Abs(cdx_bdy))*a_lift +
+		(math.Abs(cdx_ady)+math.Abs(adx_cdy))*b_lift +
+		(math.Abs(adx_bdy)+math.Abs(bdx_ady))*c_lift
+	var bound = incircle_error_bound * permanent
+	if det > bound || -det > bound {
+		return det
+	}
+	// Two equal points make two rows of the determinant equal, so it is exactly
+	// zero. The merge step of TriangulateDivideConquer asks this often, and it is
+	// too common to leave to the exact arithmetic.
+	if a == b || a == c || a == d || b == c || b == d || c == d {
+		return 0
+	}
+	if !finite(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y) {
+		return math.NaN()
+	}
+	return incircleExact(a, b, c, d)
+}
+
+// IncircleDeterminant using exact rational arithmetic
+func incircleExact(a, b, c, d Point) float64 {
+	adx, ady := sub(rat(a.X), rat(d.X)), sub(rat(a.Y), rat(d.Y))
+	bdx, bdy := sub(rat(b.X), rat(d.X)), sub(rat(b.Y), rat(d.Y))
+	cdx, cdy := sub(rat(c.X), rat(d.X)), sub(rat(c.Y), rat(d.Y))
+
+	a_lift := add(mul(adx, adx), mul(ady, ady))
+	b_lift := add(mul(bdx, bdx), mul(bdy, bdy))
+	c_lift := add(mul(cdx, cdx), mul(cdy, cdy))
+
+	det := mul(a_lift, sub(mul(bdx, cdy), mul(cdx, bdy)))
+	det = add(det, mul(b_lift, sub(mul(cdx, ady), mul(adx, cdy))))
+	det = add(det, mul(c_lift, sub(mul(adx, bdy), mul(bdx, ady))))
+	return ratFloat64(det)
+}
+
+// Given four points in space, determine the orientation of a, b, c, d
+// Return: Positive when d is below the plane through a, b and c, which appear
+// counter-clockwise when viewed from above, negative when it is above, and zero
+// when the four points are coplanar. The magnitude is approximately six times the
+// volume of the tetrahedron. NaN if a coordinate is not finite.
+func orient3d(a, b, c, d Point3D) float64 {
+	var adx, ady, adz = a.X - d.X, a.Y - d.Y, a.Z - d.Z
+	var bdx, bdy, bdz = b.X - d.X, b.Y - d.Y, b.Z - d.Z
+	var cdx, cdy, cdz = c.X - d.X, c.Y - d.Y, c.Z - d.Z
+
+	var bdy_cdz, bdz_cdy = bdy * cdz, bdz * cdy
+	var cdy_adz, cdz_ady = cdy * adz, cdz * ady
+	var ady_bdz, adz_bdy = ady * bdz, adz * bdy
+
+	var det = adx*(bdy_cdz-bdz_cdy) + bdx*(cdy_adz-cdz_ady) + cdx*(ady_bdz-adz_bdy)
+
+	var permanent = (math.Abs(bdy_cdz)+math.Abs(bdz_cdy))*math.Abs(adx) +
+		(math.Abs(cdy_adz)+math.Abs(cdz_ady))*math.Abs(bdx) +
+		(math.Abs(ady_bdz)+math.Abs(adz_bdy))*math.Abs(cdx)
+	var bound = orient3d_error_bound * permanent
+	if det > bound || -det > bound {
+		return det
+	}
+	if !finite(a.X, a.Y, a.Z, b.X, b.Y, b.Z, c.X, c.Y, c.Z, d.X, d.Y, d.Z) {
+		return math.NaN()
+	}
+	return orient3dExact(a, b, c, d)
+}
+
+// orient3d using exact rational arithmetic
+func orient3dExact(a, b, c, d Point3D) float64 {
+	adx, ady, adz := sub(rat(a.X), rat(d.X)), sub(rat(a.Y), rat(d.Y)), sub(rat(a.Z), rat(d.Z))
+	bdx, bdy, bdz := sub(rat(b.X), rat(d.X)), sub(rat(b.Y), rat(d.Y)), sub(rat(b.Z), rat(d.Z))
+	cdx, cdy, cdz := sub(rat(c.X), rat(d.X)), sub(rat(c.Y), rat(d.Y)), sub(rat(c.Z), rat(d.Z))
+
+	det := mul(adx, sub(mul(bdy, cdz), mul(bdz, cdy)))
+	det = add(det, mul(bdx, sub(mul(cdy, adz), mul(cdz, ady))))
+	det = add(det, mul(cdx, sub(mul(ady, bdz), mul(adz, bdy))))
+	return ratFloat64(det)
+}
+
+// Given five points in space, determine where e lies relative to the sphere
+// through a, b, c and d
+// Return: Positive when e is inside the sphere and orient3d(a, b, c, d) is
+// positive, negative when e is outside, and zero when the five points are
+// cospherical. The sign is reversed when orient3d(a, b, c, d) is negative. NaN if
+// a coordinate is not finite.
+func insphere(a, b, c, d, e Point3D) float64 {
+	var aex, aey, aez = a.X - e.X, a.Y - e.Y, a.Z - e.Z
+	var bex, bey, bez = b.X - e.X, b.Y - e.Y, b.Z - e.Z
+	var cex, cey, cez = c.X - e.X, c.Y - e.Y, c.Z - e.Z
+	var dex, dey, dez = d.X - e.X, d.Y - e.Y, d.Z - e.Z
+
+	var ab = aex*bey - bex*aey
+	var bc = bex*cey - cex*bey
+	var cd = cex*dey - dex*cey
+	var da = dex*aey - aex*dey
+	var ac = aex*cey - cex*aey
+	var bd = bex*dey - dex*bey
+
+	var abc = aez*bc - bez*ac + cez*ab
+	var bcd = bez*cd - cez*bd + dez*bc
+	var cda = cez*da + dez*ac + aez*cd
+	var dab = dez*ab + aez*bd + bez*da
+
+	var a_lift = aex*aex + aey*aey + aez*aez
+	var b_lift = bex*bex + bey*bey + bez*bez
+	var c_lift = cex*cex + cey*cey + cez*cez
+	var d_lift = dex*dex + dey*dey + dez*dez
+
+	var det = (d_lift*abc - c_lift*dab) + (b_lift*cda - a_lift*bcd)
+
+	var ab_p = math.Abs(aex*bey) + math.Abs(bex*aey)
+	var bc_p = math.Abs(bex*cey) + math.Abs(cex*bey)
+	var cd_p = math.Abs(cex*dey) + math.Abs(dex*cey)
+	var da_p = math.Abs(dex*aey) + math.Abs(aex*dey)
+	var ac_p = math.Abs(aex*cey) + math.Abs(cex*aey)
+	var bd_p = math.Abs(bex*dey) + math.Abs(dex*bey)
+
+	var permanent = d_lift*(math.Abs(aez)*bc_p+math.Abs(bez)*ac_p+math.Abs(cez)*ab_p) +
+		c_lift*(math.Abs(dez)*ab_p+math.Abs(aez)*bd_p+math.Abs(bez)*da_p) +
+		b_lift*(math.Abs(cez)*da_p+math.Abs(dez)*ac_p+math.Abs(aez)*cd_p) +
+		a_lift*(math.Abs(bez)*cd_p+math.Abs(cez)*bd_p+math.Abs(dez)*bc_p)
+	var bound = insphere_error_bound * permanent
+	if det > bound || -det > bound {
+		return det
+	}
+	if !finite(a.X, a.Y, a.Z, b.X, b.Y, b.Z, c.X, c.Y, c.Z, d.X, d.Y, d.Z, e.X, e.Y, e.Z) {
+		return math.NaN()
+	}
+	return insphereExact(a, b, c, d, e)
+}
+
+// insphere using exact rational arithmetic
+func insphereExact(a, b, c, d, e Point3D) float64 {
+	aex, aey, aez := sub(rat(a.X), rat(e.X)), sub(rat(a.Y), rat(e.Y)), sub(rat(a.Z), rat(e.Z))
+	bex, bey, bez := sub(rat(b.X), rat(e.X)), sub(rat(b.Y), rat(e.Y)), sub(rat(b.Z), rat(e.Z))
+	cex, cey, cez := sub(rat(c.X), rat(e.X)), sub(rat(c.Y), rat(e.Y)), sub(rat(c.Z), rat(e.Z))
+	dex, dey, dez := sub(rat(d.X), rat(e.X)), sub(rat(d.Y), rat(e.Y)), sub(rat(d.Z), rat(e.Z))
+
+	ab := sub(mul(aex, bey), mul(bex, aey))
+	bc := sub(mul(bex, cey), mul(cex, bey))
+	cd := sub(mul(cex, dey), mul(dex, cey))
+	da := sub(mul(dex, aey), mul(aex, dey))
+	ac := sub(mul(aex, cey), mul(cex, aey))
+	bd := sub(mul(bex, dey), mul(dex, bey))
+
+	abc := add(sub(mul(aez, bc), mul(bez, ac)), mul(cez, ab))
+	bcd := add(sub(mul(bez, cd), mul(cez, bd)), mul(dez, bc))
+	cda := add(add(mul(cez, da), mul(dez, ac)), mul(aez, cd))
+	dab := add(add(mul(dez, ab), mul(aez, bd)), mul(bez, da))
+
+	a_lift := add(add(mul(aex, aex), mul(aey, aey)), mul(aez, aez))
+	b_lift := add(add(mul(bex, bex), mul(bey, bey)), mul(bez, bez))
+	c_lift := add(add(mul(cex, cex), mul(cey, cey)), mul(cez, cez))
+	d_lift := add(add(mul(dex, dex), mul(dey, dey)), mul(dez, dez))
+
+	det := add(sub(mul(d_lift, abc), mul(c_lift, dab)), sub(mul(b_lift, cda), mul(a_lift, bcd)))
+	return ratFloat64(det)
+}
+
+// Given a triangle and a point, determine if the point is inside or on the
+// triangle's circumcircle using the exact predicates
+// Return: True if point is contained, false if it is outside or the triangle's
+// vertices are exactly collinear
+func robustCircumcircleContains(t Triangle, p Point) bool {
+	var orientation = Orient2D(t.A, t.B, t.C)
+	if orientation == 0 {
+		return false
+	}
+
+	var in = IncircleDeterminant(t.A, t.B, t.C, p)
+	if orientation < 0 {
+		in = -in
+	}
+	return in >= 0
+}
+
+// Determine if every value is finite, as it must be to have an exact value
+// NaN and infinite coordinates make the floating point result NaN or infinite,
+// which never passes the error bound, so this is only checked before the exact
+// arithmetic.
+func finite(values ...float64) bool {
+	for _, x := range values {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func rat(x float64) *big.Rat {
+	return new(big.Rat).SetFloat64(x)
+}
+
+func add(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Add(x, y)
+}
+
+func sub(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(x, y)
+}
+
+func mul(x, y *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(x, y)
+}
+
+// Given an exact value, round it to a float64 without losing its sign
+func ratFloat64(x *big.Rat) float64 {
+	f, _ := x.Float64()
+	if f == 0 && x.Sign() != 0 {
+		return float64(x.Sign()) * math.SmallestNonzeroFloat64
+	}
+	return f
+}
@@ -0,0 +1,173 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIncircleCocircular(t *testing.T) {
+	for _, offset := range []float64{0, 1e6, 1e12, -3.5e15} {
+		shift := func(x, y float64) Point { return Point{x + offset, y + offset} }
+		a, b, c := shift(0, 0), shift(1, 0), shift(1, 1)
+		above := Point{offset, math.Nextafter(1+offset, math.Inf(1))}
+		below := Point{offset, math.Nextafter(1+offset, math.Inf(-1))}
+		tests := []struct {
+			d    Point
+			sign int
+		}{
+			{shift(0, 1), 0},
+			{above, -1},
+			{below, 1},
+		}
+		for _, test := range tests {
+			if got := sign(IncircleDeterminant(a, b, c, test.d)); got != test.sign {
+				t.Errorf("IncircleDeterminant(%v, %v, %v, %v) has sign %d, want %d", a, b, c, test.d, got, test.sign)
+			}
+			if got := (Triangle{a, b, c}).CircumcircleContains(test.d); got != (test.sign >= 0) {
+				t.Errorf("%v.CircumcircleContains(%v) = %v", Triangle{a, b, c}, test.d, got)
+			}
+		}
+
+		// The square with a corner nudged out must use the diagonal away from it
+		points := []Point{a, b, c, above}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Errorf("offset %v: %v", offset, err)
+		}
+		if !edgeSet(triangles)[Edge{a, c}.normalized()] {
+			t.Errorf("offset %v: got %v, want the diagonal %v-%v", offset, triangles, a, c)
+		}
+	}
+}
+
+func TestTriangulateCocircularLattice(t *testing.T) {
+	// Every square of a lattice has four cocircular corners
+	for _, offset := range []float64{0, 1e8, 1e14} {
+		points := latticePoints(12)
+		for i := range points {
+			points[i] = points[i].Add(Point{offset, offset})
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Errorf("offset %v: %v", offset, err)
+		}
+		if len(triangles) != 2*11*11 {
+			t.Errorf("offset %v: got %d triangles, want %d", offset, len(triangles), 2*11*11)
+		}
+	}
+}
+
+func TestIncircleDeterminant(t *testing.T) {
+	// The circle through a, b and c has center 1, 1 and radius the square root of 2
+	a, b, c := Point{0, 0}, Point{2, 0}, Point{2, 2}
+	tests := []struct {
+		name string
+		d    Point
+		sign int
+	}{
+		{"center", Point{1, 1}, 1},
+		{"inside near the edge", Point{1, -0.4}, 1},
+		{"outside", Point{3, 3}, -1},
+		{"outside near the edge", Point{1, -0.5}, -1},
+		{"on", Point{0, 2}, 0},
+		{"vertex", a, 0},
+	}
+	for _, test := range tests {
+		if got := sign(IncircleDeterminant(a, b, c, test.d)); got != test.sign {
+			t.Errorf("%s: IncircleDeterminant(%v) has sign %d, want %d", test.name, test.d, got, test.sign)
+		}
+		if reversed := sign(IncircleDeterminant(a, c, b, test.d)); reversed != -test.sign {
+			t.Errorf("%s: clockwise IncircleDeterminant(%v) has sign %d, want %d", test.name, test.d, reversed, -test.sign)
+		}
+	}
+}
+
+func TestOrient2D(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c Point
+		sign    int
+	}{
+		{"counter-clockwise", Point{0, 0}, Point{1, 0}, Point{0, 1}, 1},
+		{"clockwise", Point{0, 0}, Point{0, 1}, Point{1, 0}, -1},
+		{"collinear", Point{0, 0}, Point{1, 1}, Point{3, 3}, 0},
+		{"repeated point", Point{2, 5}, Point{2, 5}, Point{7, 1}, 0},
+		{"collinear far out", Point{1e15, 1e15}, Point{1e15 + 2, 1e15 + 2}, Point{1e15 + 8, 1e15 + 8}, 0},
+		{"one ulp left", Point{0.5, 0.5}, Point{12, 12}, Point{24, math.Nextafter(24, math.Inf(1))}, 1},
+		{"one ulp right", Point{0.5, 0.5}, Point{12, 12}, Point{24, math.Nextafter(24, math.Inf(-1))}, -1},
+	}
+	for _, test := range tests {
+		got := Orient2D(test.a, test.b, test.c)
+		if sign(got) != test.sign {
+			t.Errorf("%s: Orient2D(%v, %v, %v) = %v, want sign %d", test.name, test.a, test.b, test.c, got, test.sign)
+		}
+		// Swapping two points reverses the orientation, rotating them keeps it
+		if swapped := sign(Orient2D(test.b, test.a, test.c)); swapped != -test.sign {
+			t.Errorf("%s: swapped Orient2D has sign %d, want %d", test.name, swapped, -test.sign)
+		}
+		if rotated := sign(Orient2D(test.b, test.c, test.a)); rotated != test.sign {
+			t.Errorf("%s: rotated Orient2D has sign %d, want %d", test.name, rotated, test.sign)
+		}
+	}
+
+	if got := Orient2D(Point{0, 0}, Point{2, 0}, Point{0, 3}); got != 6 {
+		t.Errorf("Orient2D of a 2 by 3 right triangle = %v, want twice its area, 6", got)
+	}
+}
+
+func TestIncircleRepeatedPoint(t *testing.T) {
+	a, b, c := Point{0.1, 0.3}, Point{0.7, 0.2}, Point{0.4, 0.9}
+	for _, points := range [][4]Point{{a, b, c, a}, {a, b, c, c}, {a, a, b, c}, {a, b, b, Point{5, 5}}} {
+		if got := IncircleDeterminant(points[0], points[1], points[2], points[3]); got != 0 {
+			t.Errorf("IncircleDeterminant%v = %v, want 0", points, got)
+		}
+	}
+
+	points := randomPoints(1, 1000)
+	if allocs := testing.AllocsPerRun(3, func() { TriangulateDivideConquer(points) }); allocs > 200 {
+		t.Errorf("TriangulateDivideConquer of random points made %v allocations, want few", allocs)
+	}
+}
+
+func TestPredicatesNonFinite(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{1, 0}, Point{0, 1}, Point{1, 1}
+	for _, bad := range []Point{{math.NaN(), 0}, {0, math.Inf(1)}, {math.Inf(-1), math.Inf(1)}} {
+		for k := 0; k < 4; k++ {
+			p := [4]Point{a, b, c, d}
+			p[k] = bad
+			if k < 3 {
+				if got := Orient2D(p[0], p[1], p[2]); !math.IsNaN(got) {
+					t.Errorf("Orient2D%v = %v, want NaN", p[:3], got)
+				}
+			}
+			if got := IncircleDeterminant(p[0], p[1], p[2], p[3]); !math.IsNaN(got) {
+				t.Errorf("IncircleDeterminant%v = %v, want NaN", p, got)
+			}
+			if (Triangle{p[0], p[1], p[2]}).CircumcircleContains(p[3]) {
+				t.Errorf("%v.CircumcircleContains(%v) = true, want false", Triangle{p[0], p[1], p[2]}, p[3])
+			}
+
+			q := [5]Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+			q[k] = Point3D{bad.X, bad.Y, 0}
+			if got := orient3d(q[0], q[1], q[2], q[3]); !math.IsNaN(got) {
+				t.Errorf("orient3d%v = %v, want NaN", q[:4], got)
+			}
+			if got := insphere(q[0], q[1], q[2], q[3], q[4]); !math.IsNaN(got) {
+				t.Errorf("insphere%v = %v, want NaN", q, got)
+			}
+		}
+	}
+
+	// Finite coordinates so large that the floating point result overflows are
+	// still decided exactly
+	huge := 1e300
+	if got := Orient2D(Point{0, 0}, Point{huge, 0}, Point{0, huge}); got <= 0 {
+		t.Errorf("Orient2D of a huge counter-clockwise triangle = %v, want positive", got)
+	}
+}
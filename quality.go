@@ -0,0 +1,55 @@
+package bowyer_watson
+
+import (
+	"math"
+)
+
+// Triangle method
+// Determines the interior angle at each vertex, from the dot product of the two
+// edge vectors leaving the vertex and the (shared) size of their cross product,
+// which stays accurate for angles near 0 and near Pi. The angles sum to Pi for a
+// triangle with non-zero area; a collinear triangle has angles of 0, 0 and Pi,
+// or all 0 when two vertices coincide.
+// Return: The angles at A, B and C in radians
+func (t Triangle) Angles() [3]float64 {
+	var cross = math.Abs(Orient2D(t.A, t.B, t.C))
+	angle := func(vertex, p, q Point) float64 {
+		return math.Atan2(cross, p.Sub(vertex).Dot(q.Sub(vertex)))
+	}
+	return [3]float64{angle(t.A, t.B, t.C), angle(t.B, t.C, t.A), angle(t.C, t.A, t.B)}
+}
+
+// Triangle method
+// Determines the smallest interior angle
+// Return: The angle in radians, or 0 for a degenerate triangle
+func (t Triangle) MinAngle() float64 {
+	if t.Degenerate() {
+		return 0
+	}
+	angles := t.Angles()
+	return math.Min(angles[0], math.Min(angles[1], angles[2]))
+}
+
+// Triangle method
+// Determines the largest interior angle
+// Return: The angle in radians, or math.Pi for a degenerate triangle
+func (t Triangle) MaxAngle() float64 {
+	if t.Degenerate() {
+		return math.Pi
+	}
+	angles := t.Angles()
+	return math.Max(angles[0], math.Max(angles[1], angles[2]))
+}
+
+// Triangle method
+// Determines the ratio of the circumradius to the shortest side, scaled so that
+// an equilateral triangle scores 1. It grows without bound as the smallest angle
+// shrinks, and is the quantity Refine keeps small.
+// Return: The aspect ratio, or +Inf for a degenerate triangle
+func (t Triangle) AspectRatio() float64 {
+	if t.Degenerate() {
+		return math.Inf(1)
+	}
+	var shortest = math.Sqrt(math.Min(t.A.DistanceSq(t.B), math.Min(t.B.DistanceSq(t.C), t.C.DistanceSq(t.A))))
+	return math.Sqrt(3) * t.CircumRadius() / shortest
+}
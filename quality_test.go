@@ -0,0 +1,88 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQualityEquilateral(t *testing.T) {
+	triangle := Triangle{Point{-1, 0}, Point{1, 0}, Point{0, math.Sqrt(3)}}
+	for i, angle := range triangle.Angles() {
+		if math.Abs(angle-math.Pi/3) > 1e-12 {
+			t.Errorf("angle %d is %v, want Pi/3", i, angle)
+		}
+	}
+	if got := triangle.MinAngle(); math.Abs(got-math.Pi/3) > 1e-12 {
+		t.Errorf("MinAngle() = %v, want Pi/3", got)
+	}
+	if got := triangle.MaxAngle(); math.Abs(got-math.Pi/3) > 1e-12 {
+		t.Errorf("MaxAngle() = %v, want Pi/3", got)
+	}
+	if got := triangle.AspectRatio(); math.Abs(got-1) > 1e-12 {
+		t.Errorf("AspectRatio() = %v, want 1", got)
+	}
+}
+
+func TestQualitySliver(t *testing.T) {
+	triangle := Triangle{Point{0, 0}, Point{1, 0}, Point{0.5, 0.01}}
+	base := math.Atan(0.02)
+	if got := triangle.MinAngle(); math.Abs(got-base) > 1e-12 {
+		t.Errorf("MinAngle() = %v, want %v", got, base)
+	}
+	if got := triangle.MaxAngle(); math.Abs(got-(math.Pi-2*base)) > 1e-12 {
+		t.Errorf("MaxAngle() = %v, want %v", got, math.Pi-2*base)
+	}
+	// By the law of sines the shortest side is twice the circumradius times the
+	// sine of the smallest angle
+	if got, want := triangle.AspectRatio(), math.Sqrt(3)/(2*math.Sin(base)); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("AspectRatio() = %v, want %v", got, want)
+	}
+}
+
+func TestQualityDegenerate(t *testing.T) {
+	for _, triangle := range []Triangle{
+		{Point{0, 0}, Point{1, 1}, Point{2, 2}},
+		{Point{1, 1}, Point{1, 1}, Point{1, 1}},
+	} {
+		if got := triangle.MinAngle(); got != 0 {
+			t.Errorf("%v.MinAngle() = %v, want 0", triangle, got)
+		}
+		if got := triangle.MaxAngle(); got != math.Pi {
+			t.Errorf("%v.MaxAngle() = %v, want Pi", triangle, got)
+		}
+		if got := triangle.AspectRatio(); !math.IsInf(got, 1) {
+			t.Errorf("%v.AspectRatio() = %v, want +Inf", triangle, got)
+		}
+	}
+}
+
+func TestAngles(t *testing.T) {
+	tests := []struct {
+		triangle Triangle
+		want     [3]float64
+	}{
+		{Triangle{Point{0, 0}, Point{4, 0}, Point{0, 3}}, [3]float64{math.Pi / 2, math.Atan2(3, 4), math.Atan2(4, 3)}},
+		{Triangle{Point{4, 0}, Point{0, 3}, Point{0, 0}}, [3]float64{math.Atan2(3, 4), math.Atan2(4, 3), math.Pi / 2}},
+		{Triangle{Point{0, 0}, Point{2, 0}, Point{1, math.Sqrt(3)}}, [3]float64{math.Pi / 3, math.Pi / 3, math.Pi / 3}},
+		{Triangle{Point{0, 0}, Point{1, 0}, Point{3, 0}}, [3]float64{0, math.Pi, 0}},
+		{Triangle{Point{0, 0}, Point{0, 0}, Point{3, 0}}, [3]float64{0, 0, 0}},
+	}
+	for _, test := range tests {
+		got := test.triangle.Angles()
+		for k := range got {
+			if math.Abs(got[k]-test.want[k]) > 1e-12 {
+				t.Errorf("%v.Angles() = %v, want %v", test.triangle, got, test.want)
+				break
+			}
+		}
+	}
+
+	// The angles of any triangle sum to Pi, whichever way it is wound
+	for seed := int64(0); seed < 100; seed++ {
+		p := randomPoints(seed, 3)
+		angles := Triangle{p[0], p[1], p[2]}.Angles()
+		if sum := angles[0] + angles[1] + angles[2]; math.Abs(sum-math.Pi) > 1e-12 {
+			t.Errorf("the angles of %v sum to %v", p, sum)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Given an array of points, return their Delaunay triangles by brute force
+// This is a test-only oracle for the other triangulation functions. Every triple
+// of points is tried, and kept if it is not collinear and no other point is
+// strictly inside its circumcircle, using the exact predicates. It takes O(n^4)
+// time, so it is only meant for a few dozen points.
+// For points in general position the result is the Delaunay triangulation. When
+// four or more points are on a circle with no point inside it, every triangle of
+// them passes, so the result has overlapping triangles. Repeated points are used
+// once.
+// Return: The triangles, counter-clockwise, ordered by their vertices sorted by X
+// and then Y
+func referenceTriangulate(points []Point) []Triangle {
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return pointLess(sorted[i], sorted[j]) })
+
+	distinct := sorted[:0]
+	for _, p := range sorted {
+		if len(distinct) == 0 || distinct[len(distinct)-1] != p {
+			distinct = append(distinct, p)
+		}
+	}
+
+	var triangles []Triangle
+	for i := 0; i < len(distinct); i++ {
+		for j := i + 1; j < len(distinct); j++ {
+			for k := j + 1; k < len(distinct); k++ {
+				triangle := Triangle{distinct[i], distinct[j], distinct[k]}.ToCCW()
+				if Orient2D(triangle.A, triangle.B, triangle.C) == 0 {
+					continue
+				}
+
+				empty := true
+				for _, p := range distinct {
+					if IncircleDeterminant(triangle.A, triangle.B, triangle.C, p) > 0 {
+						empty = false
+						break
+					}
+				}
+				if empty {
+					triangles = append(triangles, triangle)
+				}
+			}
+		}
+	}
+	return triangles
+}
+
+// Determines if the edges of the triangles match the reference edges
+// A Triangulation that is not complete is missing thin hull triangles, so only
+// needs its edges to be among the reference edges.
+// Return: An edge that is missing or should not be there, and false if there is one
+func matchesReference(triangles []Triangle, complete bool, want map[Edge]bool) (Edge, bool) {
+	got := edgeSet(triangles)
+	for edge := range got {
+		if !want[edge] {
+			return edge, false
+		}
+	}
+	if complete {
+		for edge := range want {
+			if !got[edge] {
+				return edge, false
+			}
+		}
+	}
+	return Edge{}, true
+}
+
+func TestMatchesReference(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		points := randomPoints(seed, 5+r.Intn(20))
+		want := edgeSet(referenceTriangulate(points))
+
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if edge, ok := matchesReference(triangles, true, want); !ok {
+			t.Fatalf("seed %d: Triangulate differs from the reference at %v", seed, edge)
+		}
+
+		triangles, err = TriangulateDivideConquer(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if edge, ok := matchesReference(triangles, true, want); !ok {
+			t.Fatalf("seed %d: TriangulateDivideConquer differs from the reference at %v", seed, edge)
+		}
+
+		triangulation := NewTriangulation(ComputeSuperTriangle(points))
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		triangles = triangulation.Triangles()
+		if edge, ok := matchesReference(triangles, triangulation.complete(triangles), want); !ok {
+			t.Fatalf("seed %d: Triangulation.Insert differs from the reference at %v", seed, edge)
+		}
+
+		// Removing points must leave the triangulation of the others
+		removed := r.Intn(len(points) - 3)
+		for _, p := range points[:removed] {
+			if err := triangulation.Remove(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want = edgeSet(referenceTriangulate(points[removed:]))
+		triangles = triangulation.Triangles()
+		if edge, ok := matchesReference(triangles, triangulation.complete(triangles), want); !ok {
+			t.Fatalf("seed %d: Triangulation.Remove of %d points differs from the reference at %v", seed, removed, edge)
+		}
+	}
+}
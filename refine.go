@@ -0,0 +1,251 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Returned (wrapped) by Refine when it stops with triangles still below the angle
+var ErrAngleNotMet = errors.New("bowyer_watson: minimum angle not reached")
+
+// Most Steiner points Refine adds, per vertex of its input, before giving up
+const refine_points_per_vertex = 10
+
+// Shortest segment Refine splits, relative to the larger side of the bounding box
+// of its input, as the midpoint of a shorter one is too close to its ends to be
+// placed reliably in floating point
+const refine_min_segment = 1e-9
+
+// Given the triangles of a Delaunay triangulation, add points until no triangle
+// has an angle below minAngle (in radians)
+// This follows Ruppert's algorithm, with the edges of the convex hull as the
+// segments to be preserved. A boundary segment with a vertex inside its diametral
+// circle is split at its midpoint; otherwise a skinny triangle gets a new point at
+// its circumcenter, unless that point would itself encroach on a segment, which is
+// then split instead.
+// The triangles must cover the convex hull of their vertices, as those of
+// Triangulate do, and the refined triangles cover the same region.
+// Segments beside a hull corner are split at powers of two from the corner, so
+// that the splits on either side do not keep encroaching on each other. The
+// algorithm is only guaranteed to finish for bounds up to about 20.7 degrees, and
+// no bound above the sharpest corner of the hull can be met at all, so
+// refinement stops after adding 10 points per input vertex, or when a segment
+// becomes too short to split reliably.
+// Return: The refined triangles, and an error wrapping ErrAngleNotMet if some of
+// them are still below minAngle, in which case the triangles are still a valid
+// refinement that is as far as it got. An error is also returned, with the input
+// triangles, if they do not cover the convex hull of their vertices.
+func Refine(triangles []Triangle, minAngle float64) ([]Triangle, error) {
+	vertices, _ := IndexTriangles(triangles)
+	if len(vertices) < 3 {
+		return triangles, nil
+	}
+	if !hasNonCollinearTriple(vertices) || len(triangles) != 2*len(vertices)-hullPointCount(vertices)-2 {
+		return triangles, fmt.Errorf("bowyer_watson: %d triangles do not cover the convex hull of their %d vertices", len(triangles), len(vertices))
+	}
+
+	triangulation := NewTriangulation(ComputeSuperTriangle(vertices))
+	for _, p := range vertices {
+		triangulation.Insert(p)
+	}
+
+	segments := boundaryEdges(triangles)
+
+	// The input segment that each segment, and each point splitting one, is part of
+	hull := append([]Edge(nil), segments...)
+	is_hull := make(map[Edge]bool, len(hull))
+	origins := make([]int, len(segments))
+	for i, segment := range hull {
+		is_hull[segment.normalized()] = true
+		origins[i] = i
+	}
+	lies_on := make(map[Point]int)
+
+	min, max := BoundingBox(vertices)
+	min_length := refine_min_segment * math.Max(max.X-min.X, max.Y-min.Y)
+
+	// Return: An error wrapping ErrAngleNotMet if the segment is too short to split
+	split := func(i int) error {
+		segment := segments[i]
+		if segment.Length() < min_length {
+			return fmt.Errorf("%w: the segment %v is too short to split", ErrAngleNotMet, segment)
+		}
+		mid := segment.Midpoint()
+		_, a_split := lies_on[segment.A]
+		_, b_split := lies_on[segment.B]
+		if a_split != b_split {
+			// Splitting at a power of two from the input vertex keeps the splits
+			// of two segments meeting at a sharp corner from encroaching on
+			// each other without end
+			corner, other := segment.A, segment.B
+			if a_split {
+				corner, other = other, corner
+			}
+			length := segment.Length()
+			distance := math.Exp2(math.Round(math.Log2(length / 2)))
+			along := distance / length
+			mid = Point{corner.X + along*(other.X-corner.X), corner.Y + along*(other.Y-corner.Y)}
+		}
+		mid = insideEdge(hull[origins[i]], mid)
+		triangulation.Insert(mid)
+		lies_on[mid] = origins[i]
+		segments[i] = Edge{segment.A, mid}
+		segments = append(segments, Edge{mid, segment.B})
+		origins = append(origins, origins[i])
+		return nil
+	}
+
+	// The points splitting a segment are rarely exactly on it in floating point,
+	// which leaves flat slivers with all their corners on one input segment. This
+	// determines if the points are all on one input segment.
+	along_hull := func(points ...Point) bool {
+		for _, p := range points {
+			origin, ok := lies_on[p]
+			if !ok {
+				continue
+			}
+			for _, q := range points {
+				if other, ok := lies_on[q]; ok && other != origin || !ok && q != hull[origin].A && q != hull[origin].B {
+					return false
+				}
+			}
+			return true
+		}
+		return len(points) == 2 && is_hull[Edge{points[0], points[1]}.normalized()]
+	}
+
+	limit := refine_points_per_vertex * len(vertices)
+	for added := 0; ; added++ {
+		current := triangulation.Triangles()
+		for _, edge := range boundaryEdges(current) {
+			if !along_hull(edge.A, edge.B) {
+				// Triangles along the hull other than slivers were lost to the
+				// super triangle
+				current, _ = TriangulateDivideConquer(triangulation.inserted.points())
+				break
+			}
+		}
+
+		// No point can improve a sliver, and it covers no real area, so it is
+		// left out of the result
+		skinny := -1
+		remaining := 0
+		kept := make([]Triangle, 0, len(current))
+		for _, triangle := range current {
+			if along_hull(triangle.A, triangle.B, triangle.C) {
+				continue
+			}
+			if triangle.MinAngle() < minAngle {
+				if skinny < 0 {
+					skinny = len(kept)
+				}
+				remaining++
+			}
+			kept = append(kept, triangle)
+		}
+
+		// The vertex across each edge, to find encroached segments
+		opposite := make(map[Edge][]Point, 3*len(current))
+		for _, triangle := range current {
+			for _, edge := range triangle.edges() {
+				key := edge.normalized()
+				opposite[key] = append(opposite[key], triangle.opposite(edge))
+			}
+		}
+
+		encroached := -1
+		for i, segment := range segments {
+			across, ok := opposite[segment.normalized()]
+			if !ok {
+				encroached = i
+				break
+			}
+			for _, p := range across {
+				if insideDiametralCircle(segment, p) {
+					encroached = i
+					break
+				}
+			}
+			if encroached >= 0 {
+				break
+			}
+		}
+		if encroached >= 0 && added < limit {
+			if err := split(encroached); err != nil {
+				return kept, err
+			}
+			continue
+		}
+
+		if skinny < 0 {
+			return kept, nil
+		}
+		if added >= limit {
+			return kept, fmt.Errorf("%w: %d triangles still below it after adding %d points", ErrAngleNotMet, remaining, limit)
+		}
+
+		center := kept[skinny].Circumcenter()
+		if math.IsNaN(center.X) {
+			return kept, fmt.Errorf("%w: the skinny triangle %v is too flat for a circumcenter", ErrAngleNotMet, kept[skinny])
+		}
+		split_any := false
+		for i := 0; i < len(segments); i++ {
+			if insideDiametralCircle(segments[i], center) {
+				if err := split(i); err != nil {
+					return kept, err
+				}
+				split_any = true
+				break
+			}
+		}
+		if split_any {
+			continue
+		}
+		if _, inside := Locate(current, center); !inside {
+			// Only possible through round-off, as the hull segments are not encroached
+			return kept, fmt.Errorf("%w: the circumcenter %v of a skinny triangle is outside the mesh", ErrAngleNotMet, center)
+		}
+		triangulation.Insert(center)
+	}
+}
+
+// Given an edge directed counter-clockwise around a region and a point close to
+// the edge, move the point the fewest steps of one unit in the last place needed
+// to put it on the edge's line or on the region's side of it
+// Return: The moved point, which is p if it is already on or inside the line
+func insideEdge(e Edge, p Point) Point {
+	// Pointing into the region, to the left of the edge
+	into_x, into_y := e.A.Y-e.B.Y, e.B.X-e.A.X
+	for Orient2D(e.A, e.B, p) < 0 {
+		if into_x != 0 {
+			p.X = math.Nextafter(p.X, math.Copysign(math.Inf(1), into_x))
+		}
+		if into_y != 0 {
+			p.Y = math.Nextafter(p.Y, math.Copysign(math.Inf(1), into_y))
+		}
+	}
+	return p
+}
+
+// Given an array of triangles, split each one into four, levels times over
+// Each split joins the midpoints of a triangle's edges, giving three corner
+// triangles with the same winding as the original and one in the middle. The
+// midpoint of an edge does not depend on its direction, so triangles sharing an
+// edge share its midpoint and no T-junctions appear. Unlike Refine this keeps the
+// shape of every triangle, and the result is generally not Delaunay.
+// Return: 4^levels triangles per input triangle, or the input unchanged if levels
+// is not positive
+func Subdivide(triangles []Triangle, levels int) []Triangle {
+	for level := 0; level < levels; level++ {
+		split := make([]Triangle, 0, 4*len(triangles))
+		for _, t := range triangles {
+			ab := Edge{t.A, t.B}.Midpoint()
+			bc := Edge{t.B, t.C}.Midpoint()
+			ca := Edge{t.C, t.A}.Midpoint()
+			split = append(split, Triangle{t.A, ab, ca}, Triangle{ab, t.B, bc}, Triangle{ca, bc, t.C}, Triangle{ab, bc, ca})
+		}
+		triangles = split
+	}
+	return triangles
+}
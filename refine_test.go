@@ -0,0 +1,131 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRefineReachesAngle(t *testing.T) {
+	bound := 20 * math.Pi / 180
+	for seed := int64(0); seed < 40; seed++ {
+		points := randomPoints(seed, 40)
+		if seed%2 == 0 {
+			for i := range points {
+				points[i].Y *= 0.3
+			}
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refined, err := Refine(triangles, bound)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		for _, triangle := range refined {
+			if triangle.MinAngle() < bound {
+				t.Fatalf("seed %d: %v has an angle of %v degrees", seed, triangle, triangle.MinAngle()*180/math.Pi)
+			}
+		}
+		if err := Validate(refined); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if got, want := TotalArea(refined), TotalArea(triangles); math.Abs(got-want) > 1e-9*want {
+			t.Fatalf("seed %d: refined area %v, want %v", seed, got, want)
+		}
+	}
+}
+
+func TestRefineReportsUnreachableAngle(t *testing.T) {
+	triangles, err := Triangulate(randomPoints(1, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	refined, err := Refine(triangles, 50*math.Pi/180)
+	if !errors.Is(err, ErrAngleNotMet) {
+		t.Fatalf("Refine() error = %v, want ErrAngleNotMet", err)
+	}
+	if err := Validate(refined); err != nil {
+		t.Error(err)
+	}
+	if got, want := TotalArea(refined), TotalArea(triangles); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("refined area %v, want %v", got, want)
+	}
+}
+
+func TestRefineRejectsPartialCover(t *testing.T) {
+	triangles, err := Triangulate([]Point{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Refine(triangles[1:], 20*math.Pi/180); err == nil {
+		t.Error("Refine accepted triangles missing part of the hull")
+	}
+}
+
+func TestRefineSharpCorner(t *testing.T) {
+	// The corner at the origin is about 11 degrees, so no refinement of it can
+	// reach 20
+	points := []Point{{0, 0}, {10, 1}, {10, -1}, {8, 0.2}, {9, -0.3}}
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refined, err := Refine(triangles, 20*math.Pi/180)
+	if !errors.Is(err, ErrAngleNotMet) {
+		t.Fatalf("Refine() error = %v, want ErrAngleNotMet", err)
+	}
+	if err := Validate(refined); err != nil {
+		t.Error(err)
+	}
+	if got, want := TotalArea(refined), TotalArea(triangles); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("refined area %v, want %v", got, want)
+	}
+
+	// A corner of about 38 degrees is refined by splitting on concentric shells
+	points = []Point{{0, 0}, {10, 3.4}, {10, -3.4}, {8, 0.2}, {9, -0.3}}
+	triangles, err = Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Refine(triangles, 20*math.Pi/180); err != nil {
+		t.Errorf("Refine() with a 38 degree corner: %v", err)
+	}
+}
+
+func TestSubdivide(t *testing.T) {
+	points := append(randomPoints(79, 50), latticePoints(3)...)
+	triangles := mustTriangulate(t, points)
+	area := TotalArea(triangles)
+
+	for levels, want := range []int{1, 4, 16} {
+		split := Subdivide(triangles, levels)
+		if len(split) != want*len(triangles) {
+			t.Fatalf("%d levels: got %d triangles, want %d", levels, len(split), want*len(triangles))
+		}
+		if got := TotalArea(split); math.Abs(got-area) > 1e-12*area {
+			t.Errorf("%d levels: the triangles cover %v, want %v", levels, got, area)
+		}
+		// Each triangle of the first level takes a quarter of its parent's area
+		if levels == 1 {
+			for i, triangle := range split {
+				parent := triangles[i/4]
+				if math.Abs(triangle.SignedArea()-parent.SignedArea()/4) > 1e-15 {
+					t.Errorf("%v has area %v, a quarter of %v is %v", triangle, triangle.SignedArea(), parent, parent.SignedArea()/4)
+				}
+			}
+		}
+		// A T-junction would leave an edge used by only one triangle inside the
+		// hull, so without them the boundary is just the hull's edges split in two
+		// at each level
+		if got, want := len(boundaryEdges(split)), len(boundaryEdges(triangles))<<levels; got != want {
+			t.Errorf("%d levels: got %d boundary edges, want %d", levels, got, want)
+		}
+	}
+
+	if got := Subdivide(triangles, 0); len(got) != len(triangles) {
+		t.Errorf("Subdivide(0) gave %d triangles, want %d", len(got), len(triangles))
+	}
+}
@@ -0,0 +1,131 @@
+package bowyer_watson
+
+import "math"
+
+// A Point with a weight, for the power (Laguerre) diagram
+// The power distance from a position x to the point is |x - Point|^2 - Weight, so
+// a larger weight gives the point a larger cell
+type WeightedPoint struct {
+	Point
+	Weight float64
+}
+
+// Given an array of weighted points, return an array of triangles of their
+// regular (weighted Delaunay) triangulation, the dual of the power diagram
+// This is the Bowyer-Watson algorithm with the circumcircle test replaced by a
+// test against each triangle's orthogonal circle. A point whose weight is too
+// small for it to have a cell of its own is redundant and does not appear in the
+// result, and inserting a heavy point can make an earlier point redundant. When
+// every weight is equal the result is the same as DelaunayTriangulation.
+// Super triangle is a triangle that contains all the points, see ComputeSuperTriangle.
+// Its Points have weight 0, so it must be large enough that none of them become
+// redundant.
+func RegularTriangulation(points []WeightedPoint, super_triangle Triangle) []Triangle {
+	weights := map[Point]float64{super_triangle.A: 0, super_triangle.B: 0, super_triangle.C: 0}
+	triangles := []Triangle{super_triangle}
+
+	var edges []Edge
+	for _, p := range points {
+		if _, ok := weights[p.Point]; ok {
+			continue
+		}
+
+		edges = edges[:0]
+		kept := triangles[:0]
+		for _, triangle := range triangles {
+			if powerContains(triangle, weights, p) {
+				edges = append(edges, Edge{triangle.A, triangle.B}, Edge{triangle.A, triangle.C}, Edge{triangle.B, triangle.C})
+				continue
+			}
+			kept = append(kept, triangle)
+		}
+		triangles = kept
+
+		// A point in conflict with no triangle is redundant
+		if len(edges) == 0 {
+			continue
+		}
+		weights[p.Point] = p.Weight
+
+		edge_count := make(map[Edge]int, len(edges))
+		for _, edge := range edges {
+			edge_count[edge.normalized()]++
+		}
+		for _, edge := range edges {
+			if edge_count[edge.normalized()] == 1 {
+				triangles = append(triangles, Triangle{edge.A, edge.B, p.Point})
+			}
+		}
+	}
+
+	return RemoveSuperTriangle(triangles, super_triangle)
+}
+
+// Given a triangle whose vertices have the given weights, determine if a weighted
+// point is in conflict with it, meaning its power distance to the triangle's
+// orthogonal circle is not positive
+// Return: True if the point is in conflict, false if it is not or the triangle's
+// vertices are exactly collinear
+func powerContains(t Triangle, weights map[Point]float64, p WeightedPoint) bool {
+	var orientation = Orient2D(t.A, t.B, t.C)
+	if orientation == 0 {
+		return false
+	}
+
+	var in = powerTest(t.A, t.B, t.C, p.Point, weights[t.A]-p.Weight, weights[t.B]-p.Weight, weights[t.C]-p.Weight)
+	if orientation < 0 {
+		in = -in
+	}
+	return in >= 0
+}
+
+// Given four points and the weights of a, b and c relative to the weight of d,
+// determine where d lies relative to the orthogonal circle of a, b and c
+// With every relative weight zero this is IncircleDeterminant.
+// Return: Positive when d is in conflict and a, b, c are counter-clockwise,
+// negative when it is not, and zero on the boundary. The sign is reversed when
+// a, b, c are clockwise.
+func powerTest(a, b, c, d Point, wa, wb, wc float64) float64 {
+	if wa == 0 && wb == 0 && wc == 0 {
+		return IncircleDeterminant(a, b, c, d)
+	}
+
+	var adx, ady = a.X - d.X, a.Y - d.Y
+	var bdx, bdy = b.X - d.X, b.Y - d.Y
+	var cdx, cdy = c.X - d.X, c.Y - d.Y
+
+	var bdx_cdy, cdx_bdy = bdx * cdy, cdx * bdy
+	var cdx_ady, adx_cdy = cdx * ady, adx * cdy
+	var adx_bdy, bdx_ady = adx * bdy, bdx * ady
+
+	var a_lift = adx*adx + ady*ady
+	var b_lift = bdx*bdx + bdy*bdy
+	var c_lift = cdx*cdx + cdy*cdy
+
+	var det = (a_lift-wa)*(bdx_cdy-cdx_bdy) + (b_lift-wb)*(cdx_ady-adx_cdy) + (c_lift-wc)*(adx_bdy-bdx_ady)
+
+	var permanent = (math.Abs(bdx_cdy)+math.Abs(cdx_bdy))*(a_lift+math.Abs(wa)) +
+		(math.Abs(cdx_ady)+math.Abs(adx_cdy))*(b_lift+math.Abs(wb)) +
+		(math.Abs(adx_bdy)+math.Abs(bdx_ady))*(c_lift+math.Abs(wc))
+	var bound = incircle_error_bound * permanent
+	if det > bound || -det > bound {
+		return det
+	}
+	return powerTestExact(a, b, c, d, wa, wb, wc)
+}
+
+// powerTest using exact rational arithmetic
+func powerTestExact(a, b, c, d Point, wa, wb, wc float64) float64 {
+	adx, ady := sub(rat(a.X), rat(d.X)), sub(rat(a.Y), rat(d.Y))
+	bdx, bdy := sub(rat(b.X), rat(d.X)), sub(rat(b.Y), rat(d.Y))
+	cdx, cdy := sub(rat(c.X), rat(d.X)), sub(rat(c.Y), rat(d.Y))
+
+	a_lift := sub(add(mul(adx, adx), mul(ady, ady)), rat(wa))
+	b_lift := sub(add(mul(bdx, bdx), mul(bdy, bdy)), rat(wb))
+	c_lift := sub(add(mul(cdx, cdx), mul(cdy, cdy)), rat(wc))
+
+	det := mul(a_lift, sub(mul(bdx, cdy), mul(cdx, bdy)))
+	det = add(det, mul(b_lift, sub(mul(cdx, ady), mul(adx, cdy))))
+	det = add(det, mul(c_lift, sub(mul(adx, bdy), mul(bdx, ady))))
+	return ratFloat64(det)
+}
@@ -0,0 +1,105 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Given a weighted site and the other weighted points, return the site's power
+// cell and the points whose bisector bounds it, by clipping a large box with the
+// power bisector of every other point
+func bruteForcePowerCell(site WeightedPoint, others []WeightedPoint) ([]Point, map[Point]bool) {
+	box := []Point{{-100, -100}, {100, -100}, {100, 100}, {-100, 100}}
+	clip := func(cell []Point, other WeightedPoint) []Point {
+		// The cell is where n.x <= c, with the line directed so that side is on its left
+		var n = other.Point.Sub(site.Point)
+		var c = (other.Point.Dot(other.Point) - site.Point.Dot(site.Point) - other.Weight + site.Weight) / 2
+		var on_line = Point{n.X * c / n.Dot(n), n.Y * c / n.Dot(n)}
+		return clipHalfPlane(cell, on_line, on_line.Add(Point{-n.Y, n.X}))
+	}
+
+	cell := box
+	for _, other := range others {
+		cell = clip(cell, other)
+	}
+
+	// A point is a neighbor if leaving out its bisector makes the cell larger
+	neighbors := make(map[Point]bool)
+	for i, other := range others {
+		without := box
+		for j, another := range others {
+			if j != i {
+				without = clip(without, another)
+			}
+		}
+		if PolygonArea(without)-PolygonArea(cell) > 1e-9 {
+			neighbors[other.Point] = true
+		}
+	}
+	return cell, neighbors
+}
+
+func TestRegularTriangulationEqualWeights(t *testing.T) {
+	points := randomPoints(18, 300)
+	weighted := make([]WeightedPoint, len(points))
+	for i, p := range points {
+		weighted[i] = WeightedPoint{p, 0.7}
+	}
+	super := ComputeSuperTriangle(points)
+	got, want := RegularTriangulation(weighted, super), DelaunayTriangulation(points, super)
+	SortTriangles(got)
+	SortTriangles(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("equal weights gave %d triangles, DelaunayTriangulation %d", len(got), len(want))
+	}
+}
+
+func TestRegularTriangulationWeightGrowsCell(t *testing.T) {
+	var others []WeightedPoint
+	var points []Point
+	for i, p := range randomPoints(19, 30) {
+		others = append(others, WeightedPoint{Point{p.X * 10, p.Y * 10}, float64(i%3) * 0.2})
+		points = append(points, others[i].Point)
+	}
+	center := Point{5, 5}
+	points = append(points, center)
+	super := ComputeSuperTriangle(points)
+
+	previous := 0.0
+	for _, weight := range []float64{-1, 0, 1, 3} {
+		site := WeightedPoint{center, weight}
+		cell, want := bruteForcePowerCell(site, others)
+		area := PolygonArea(cell)
+		if area <= previous {
+			t.Errorf("weight %v: cell area %v is not larger than %v", weight, area, previous)
+		}
+		previous = area
+
+		// The cell's neighbors are the site's neighbors in the regular triangulation
+		got := make(map[Point]bool)
+		for _, triangle := range RegularTriangulation(append(append([]WeightedPoint(nil), others...), site), super) {
+			if triangle.ContainsPoint(center) {
+				for _, vertex := range [3]Point{triangle.A, triangle.B, triangle.C} {
+					if vertex != center {
+						got[vertex] = true
+					}
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("weight %v: the site has neighbors %v, its power cell %v", weight, got, want)
+		}
+	}
+
+	// Too light a point has no cell and is left out
+	light := WeightedPoint{center, -10}
+	if cell, _ := bruteForcePowerCell(light, others); len(cell) != 0 {
+		t.Fatalf("weight %v: the site still has a cell of area %v", light.Weight, math.Abs(PolygonArea(cell)))
+	}
+	for _, triangle := range RegularTriangulation(append(others, light), super) {
+		if triangle.ContainsPoint(center) {
+			t.Errorf("redundant site %v is in %v", center, triangle)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+)
+
+// A point on the unit sphere by latitude and longitude, in degrees
+type SpherePoint struct {
+	Lat, Lon float64
+}
+
+// Three points on the sphere, counter-clockwise when viewed from outside it
+// The triangle is the part of the sphere bounded by the great circle arcs
+// between its vertices
+type SphereTriangle struct {
+	A, B, C SpherePoint
+}
+
+// SpherePoint method
+// Longitudes that differ by a multiple of 360, such as 180 and -180, are the
+// same meridian, and a pole is the same point whatever its longitude
+// Return: The point with its longitude in (-180, 180], and 0 at the poles
+func (p SpherePoint) normalized() SpherePoint {
+	if p.Lat == 90 || p.Lat == -90 {
+		return SpherePoint{p.Lat, 0}
+	}
+	p.Lon = math.Mod(p.Lon, 360)
+	if p.Lon <= -180 {
+		p.Lon += 360
+	} else if p.Lon > 180 {
+		p.Lon -= 360
+	}
+	return p
+}
+
+// SpherePoint method
+// The poles are mapped exactly onto the Z axis, whatever their longitude, and
+// longitudes are first taken into (-180, 180], so that the same position always
+// gives the same vector
+// Return: The point as a unit vector, with the X axis through latitude and
+// longitude 0 and the Z axis through the north pole
+func (p SpherePoint) Vector() Point3D {
+	if p.Lat == 90 || p.Lat == -90 {
+		return Point3D{0, 0, math.Copysign(1, p.Lat)}
+	}
+	p = p.normalized()
+	sin_lat, cos_lat := math.Sincos(p.Lat * math.Pi / 180)
+	sin_lon, cos_lon := math.Sincos(p.Lon * math.Pi / 180)
+	return Point3D{cos_lat * cos_lon, cos_lat * sin_lon, sin_lat}
+}
+
+// One face of the convex hull built by DelaunaySphere, as indices of its
+// vertices counter-clockwise when viewed from outside
+type hullFace struct {
+	a, b, c int
+	removed bool
+}
+
+// hullFace method
+// Return: The 3 directed edges of the face
+func (f hullFace) edges() [3][2]int {
+	return [3][2]int{{f.a, f.b}, {f.b, f.c}, {f.c, f.a}}
+}
+
+// Given an array of points on the sphere, return the triangles of their spherical
+// Delaunay triangulation
+// A circle on the sphere is where a plane cuts it, and the points inside the
+// circle are those beyond the plane, so a triangle's circumcircle is empty exactly
+// when no point is outside the plane through its vertices. The triangles are
+// therefore the faces of the convex hull of the points as unit vectors, built here
+// by adding one point at a time and replacing the faces it can see. Working with
+// vectors, the triangulation has no seam at the antimeridian and no special cases
+// at the poles. It takes O(n^2) time in the worst case.
+// When every point is in one hemisphere, the hull also has faces across the empty
+// side whose circumcircles are the larger caps, and these faces, which separate
+// the points from the center of the sphere, are left out. Points at the same
+// position are used once, the first time they are seen, including longitudes 180
+// and -180 of one latitude and a pole given with different longitudes.
+// Return: The triangles, or an error if a coordinate is not finite or the points
+// do not include four that are not on a single circle
+func DelaunaySphere(points []SpherePoint) ([]SphereTriangle, error) {
+	for i, p := range points {
+		if math.IsNaN(p.Lat) || math.IsInf(p.Lat, 0) || math.IsNaN(p.Lon) || math.IsInf(p.Lon, 0) {
+			return nil, fmt.Errorf("%w: point %d is (%v, %v)", ErrNonFinite, i, p.Lat, p.Lon)
+		}
+	}
+
+	var sites []SpherePoint
+	var vectors []Point3D
+	seen := make(map[Point3D]bool, len(points))
+	for _, p := range points {
+		v := p.Vector()
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		sites = append(sites, p)
+		vectors = append(vectors, v)
+	}
+	if len(vectors) < 4 {
+		return nil, fmt.Errorf("%w: need at least 4 distinct points, got %d", ErrTooFewPoints, len(vectors))
+	}
+
+	// Distinct points on a sphere are never collinear, so only the fourth point of
+	// the starting tetrahedron needs searching for
+	d := -1
+	for i := 3; i < len(vectors); i++ {
+		if orient3d(vectors[0], vectors[1], vectors[2], vectors[i]) != 0 {
+			d = i
+			break
+		}
+	}
+	if d < 0 {
+		return nil, fmt.Errorf("%w: all %d points lie on one circle of the sphere", ErrCollinearPoints, len(vectors))
+	}
+
+	var faces []hullFace
+	// Each directed edge to the face it belongs to
+	owner := make(map[[2]int]int, 6*len(vectors))
+	addFace := func(f hullFace) {
+		faces = append(faces, f)
+		for _, edge := range f.edges() {
+			owner[edge] = len(faces) - 1
+		}
+	}
+
+	// Each face of the tetrahedron is wound so that the opposite vertex is below it
+	corners := [4]int{0, 1, 2, d}
+	for skip := 0; skip < 4; skip++ {
+		var f []int
+		for k, corner := range corners {
+			if k != skip {
+				f = append(f, corner)
+			}
+		}
+		if orient3d(vectors[f[0]], vectors[f[1]], vectors[f[2]], vectors[corners[skip]]) > 0 {
+			addFace(hullFace{a: f[0], b: f[1], c: f[2]})
+		} else {
+			addFace(hullFace{a: f[0], b: f[2], c: f[1]})
+		}
+	}
+
+	visible := make(map[int]bool)
+	for p := 3; p < len(vectors); p++ {
+		if p == d {
+			continue
+		}
+
+		for k := range visible {
+			delete(visible, k)
+		}
+		for i, f := range faces {
+			if !f.removed && orient3d(vectors[f.a], vectors[f.b], vectors[f.c], vectors[p]) < 0 {
+				visible[i] = true
+			}
+		}
+
+		// The edges between visible and hidden faces form the horizon, and each is
+		// joined to the new point. A point seeing no face is inside the hull.
+		var horizon [][2]int
+		for i := range visible {
+			for _, edge := range faces[i].edges() {
+				if !visible[owner[[2]int{edge[1], edge[0]}]] {
+					horizon = append(horizon, edge)
+				}
+			}
+		}
+		for i := range visible {
+			faces[i].removed = true
+			for _, edge := range faces[i].edges() {
+				if owner[edge] == i {
+					delete(owner, edge)
+				}
+			}
+		}
+		for _, edge := range horizon {
+			addFace(hullFace{a: edge[0], b: edge[1], c: p})
+		}
+	}
+
+	var triangles []SphereTriangle
+	center := Point3D{}
+	for _, f := range faces {
+		if f.removed || orient3d(vectors[f.a], vectors[f.b], vectors[f.c], center) <= 0 {
+			continue
+		}
+		triangles = append(triangles, SphereTriangle{sites[f.a], sites[f.b], sites[f.c]})
+	}
+	return triangles, nil
+}
@@ -0,0 +1,93 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Return: n points spread evenly over the sphere along a Fibonacci spiral, with
+// longitudes in -180 to 180 so that many land either side of the antimeridian
+func fibonacciSphere(n int) []SpherePoint {
+	points := make([]SpherePoint, n)
+	golden := math.Pi * (3 - math.Sqrt(5))
+	for i := range points {
+		z := 1 - (2*float64(i)+1)/float64(n)
+		lon := math.Mod(float64(i)*golden, 2*math.Pi)*180/math.Pi - 180
+		points[i] = SpherePoint{math.Asin(z) * 180 / math.Pi, lon}
+	}
+	return points
+}
+
+// Return: The angle between two unit vectors, the length of the arc between them
+func arcLength(p, q Point3D) float64 {
+	cross := Point3D{p.Y*q.Z - p.Z*q.Y, p.Z*q.X - p.X*q.Z, p.X*q.Y - p.Y*q.X}
+	return math.Atan2(math.Sqrt(cross.X*cross.X+cross.Y*cross.Y+cross.Z*cross.Z), p.X*q.X+p.Y*q.Y+p.Z*q.Z)
+}
+
+func TestDelaunaySphereAcrossAntimeridian(t *testing.T) {
+	points := fibonacciSphere(200)
+	// Points just either side of the antimeridian, and the poles
+	points = append(points, SpherePoint{10, 179.99}, SpherePoint{10.5, -179.99}, SpherePoint{-20, 180},
+		SpherePoint{90, 0}, SpherePoint{-90, 45})
+	distinct := len(points)
+	// The same positions again, written differently
+	points = append(points, SpherePoint{0, 180}, SpherePoint{0, -180}, SpherePoint{-20, -180},
+		SpherePoint{90, 45}, SpherePoint{-90, -135})
+	distinct++
+	triangles, err := DelaunaySphere(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A triangulation of the whole sphere has 2n - 4 triangles, and every edge is
+	// used once in each direction
+	if want := 2*distinct - 4; len(triangles) != want {
+		t.Fatalf("got %d triangles, want %d", len(triangles), want)
+	}
+	directed := make(map[[2]Point3D]int)
+	spacing := math.Sqrt(4 * math.Pi / float64(len(points)))
+	for _, triangle := range triangles {
+		a, b, c := triangle.A.Vector(), triangle.B.Vector(), triangle.C.Vector()
+		if orient3d(a, b, c, Point3D{}) <= 0 {
+			t.Errorf("%v is not counter-clockwise from outside", triangle)
+		}
+		for _, edge := range [3][2]Point3D{{a, b}, {b, c}, {c, a}} {
+			directed[edge]++
+			// Wrapping the wrong way around the sphere would give arcs far longer
+			// than the spacing of the points
+			if arcLength(edge[0], edge[1]) > 3*spacing {
+				t.Errorf("%v has an arc of %v radians, the points are about %v apart", triangle, arcLength(edge[0], edge[1]), spacing)
+			}
+		}
+		// No point is inside the circumcircle, beyond the plane of the triangle
+		for _, p := range points {
+			if orient3d(a, b, c, p.Vector()) < 0 {
+				t.Errorf("%v is inside the circumcircle of %v", p, triangle)
+			}
+		}
+	}
+	for edge, count := range directed {
+		if count != 1 || directed[[2]Point3D{edge[1], edge[0]}] != 1 {
+			t.Errorf("the edge %v is used %d times, its reverse %d", edge, count, directed[[2]Point3D{edge[1], edge[0]}])
+		}
+	}
+}
+
+func TestDelaunaySphereErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []SpherePoint
+		want   error
+	}{
+		{"three", []SpherePoint{{0, 0}, {0, 90}, {90, 0}}, ErrTooFewPoints},
+		{"repeated", []SpherePoint{{0, 0}, {0, 90}, {90, 0}, {0, 90}}, ErrTooFewPoints},
+		{"equator", []SpherePoint{{0, 0}, {0, 90}, {0, 180}, {0, -90}, {0, 45}}, ErrCollinearPoints},
+		{"NaN", []SpherePoint{{0, 0}, {0, 90}, {90, 0}, {math.NaN(), 0}}, ErrNonFinite},
+	}
+	for _, test := range tests {
+		if _, err := DelaunaySphere(test.points); !errors.Is(err, test.want) {
+			t.Errorf("%s: DelaunaySphere() error = %v, want %v", test.name, err, test.want)
+		}
+	}
+}
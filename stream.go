@@ -0,0 +1,32 @@
+package bowyer_watson
+
+// Given an array of points, triangulate them in the background and send the
+// triangles on a channel, for pipelines that process triangles one at a time
+// A triangle is only final once no later point can fall inside its circumcircle,
+// which is not known until every point is inserted, so the triangles are sent
+// after the triangulation completes, in the order Triangulate returns them.
+// The triangle channel must be read until it is closed, or the goroutine sending
+// on it never finishes.
+// Return: A channel of the triangles, closed after the last one, and a channel
+// that receives the error from Triangulate if there is one and is then closed.
+// When there is an error no triangles are sent.
+func TriangulateStream(points []Point) (<-chan Triangle, <-chan error) {
+	triangles := make(chan Triangle)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(triangles)
+
+		result, err := Triangulate(points)
+		if err != nil {
+			errs <- err
+			return
+		}
+		for _, triangle := range result {
+			triangles <- triangle
+		}
+	}()
+
+	return triangles, errs
+}
@@ -0,0 +1,37 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTriangulateStream(t *testing.T) {
+	points := append(randomPoints(67, 300), latticePoints(4)...)
+	stream, errs := TriangulateStream(points)
+	var got []Triangle
+	for triangle := range stream {
+		got = append(got, triangle)
+	}
+	if err, open := <-errs; err != nil || open {
+		t.Fatalf("after the triangles the error channel gave %v, open %v, want it closed", err, open)
+	}
+	want, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the stream sent %d triangles, Triangulate returned %d", len(got), len(want))
+	}
+
+	stream, errs = TriangulateStream([]Point{{0, 0}, {1, 1}, {2, 2}})
+	for triangle := range stream {
+		t.Errorf("collinear points sent %v", triangle)
+	}
+	if err := <-errs; !errors.Is(err, ErrCollinearPoints) {
+		t.Errorf("collinear points gave %v, want %v", err, ErrCollinearPoints)
+	}
+	if _, open := <-errs; open {
+		t.Error("the error channel is not closed after the error")
+	}
+}
@@ -0,0 +1,96 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+)
+
+// Given an array of triangles, return the sum of their areas
+// For a triangulation that covers the convex hull of its points, as those of
+// Triangulate and TriangulateDivideConquer do, this equals
+// PolygonArea(ConvexHullOfPoints(points)) up to round-off, which makes a useful
+// check that no triangles are missing or overlapping
+func TotalArea(triangles []Triangle) float64 {
+	var total float64
+	for _, triangle := range triangles {
+		total += triangle.Area()
+	}
+	return total
+}
+
+// Given an array of triangles, return the length of the boundary of the region
+// they cover
+// The boundary is made of the edges belonging to exactly one triangle, so for a
+// triangulation that covers the convex hull of its points it is the perimeter of
+// the hull; edges shared by two triangles are not counted
+func TotalPerimeter(triangles []Triangle) float64 {
+	var total float64
+	for _, edge := range boundaryEdges(triangles) {
+		total += edge.Length()
+	}
+	return total
+}
+
+// How far a triangulation is from being Delaunay, see DelaunayReport
+type Report struct {
+	// The number of pairs of triangles sharing an edge that were checked
+	Pairs int
+	// The number of pairs in which a triangle's circumcircle strictly contains
+	// the vertex of the other triangle across their shared edge
+	Violations int
+	// How far inside the circumcircle the worst such vertex is, as a fraction of
+	// the circumradius: near 0 is barely inside and 1 is at the center. Zero when
+	// there are no violations.
+	Worst float64
+	// The shared edge of the worst pair
+	WorstEdge Edge
+}
+
+// Report method
+// Return: A one-line summary, for example "3 of 120 pairs violate the Delaunay
+// condition, worst 0.25 across [(0, 0)-(1, 1)]"
+func (r Report) String() string {
+	if r.Violations == 0 {
+		return fmt.Sprintf("all %d pairs satisfy the Delaunay condition", r.Pairs)
+	}
+	return fmt.Sprintf("%d of %d pairs violate the Delaunay condition, worst %g across %v", r.Violations, r.Pairs, r.Worst, r.WorstEdge)
+}
+
+// Given the triangles of a triangulation, measure how far it is from Delaunay
+// Each pair of triangles sharing an edge is checked with the exact in-circle test,
+// as for EnforceDelaunay, which is enough since a triangulation whose every edge
+// is locally Delaunay is Delaunay. Pairs involving a degenerate triangle are not
+// checked, having no circumcircle.
+// Return: The number of pairs checked and violating, and the worst violation
+func DelaunayReport(triangles []Triangle) Report {
+	var report Report
+	for i, neighbors := range Neighbors(triangles) {
+		for k, j := range neighbors {
+			if j < i || triangles[i].Degenerate() || triangles[j].Degenerate() {
+				continue
+			}
+			report.Pairs++
+
+			edge := triangles[i].edges()[k]
+			worst := 0.0
+			for _, pair := range [2][2]Triangle{{triangles[i], triangles[j]}, {triangles[j], triangles[i]}} {
+				triangle, across := pair[0].ToCCW(), pair[1].opposite(edge)
+				if IncircleDeterminant(triangle.A, triangle.B, triangle.C, across) > 0 {
+					radius := triangle.CircumRadius()
+					worst = math.Max(worst, math.Max(0, (radius-across.Distance(triangle.Circumcenter()))/radius))
+					if worst == 0 {
+						// Inside by less than the circumcenter's round-off
+						worst = math.SmallestNonzeroFloat64
+					}
+				}
+			}
+			if worst > 0 {
+				report.Violations++
+				if worst > report.Worst {
+					report.Worst, report.WorstEdge = worst, edge
+				}
+			}
+		}
+	}
+	return report
+}
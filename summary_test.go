@@ -0,0 +1,88 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestTotals(t *testing.T) {
+	triangles, err := Triangulate([]Point{{0, 0}, {3, 0}, {3, 4}, {0, 4}, {1, 1}, {2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := TotalArea(triangles); math.Abs(got-12) > 1e-12 {
+		t.Errorf("TotalArea() = %v, want 12", got)
+	}
+	if got := TotalPerimeter(triangles); math.Abs(got-14) > 1e-12 {
+		t.Errorf("TotalPerimeter() = %v, want 14", got)
+	}
+}
+
+func TestTotalAreaEqualsHullArea(t *testing.T) {
+	for seed := int64(0); seed < 300; seed++ {
+		points := randomPoints(seed, 30)
+		if seed%2 == 0 {
+			points = append(points, latticePoints(3)...)
+		}
+		triangles, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hull := ConvexHullOfPoints(points)
+		if got, want := TotalArea(triangles), PolygonArea(hull); math.Abs(got-want) > 1e-9*want {
+			t.Fatalf("seed %d: TotalArea() = %v, hull area %v", seed, got, want)
+		}
+
+		var perimeter float64
+		for i, p := range hull {
+			perimeter += p.Distance(hull[(i+1)%len(hull)])
+		}
+		if got := TotalPerimeter(triangles); math.Abs(got-perimeter) > 1e-9*perimeter {
+			t.Fatalf("seed %d: TotalPerimeter() = %v, hull perimeter %v", seed, got, perimeter)
+		}
+	}
+}
+
+func TestPolygonAreaFarFromOrigin(t *testing.T) {
+	square := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	for _, offset := range []float64{0, 1e8, -1e12, 1e15} {
+		shifted := make([]Point, len(square))
+		for i, p := range square {
+			shifted[i] = p.Add(Point{offset, offset})
+		}
+		if got := PolygonArea(shifted); got != 1 {
+			t.Errorf("offset %v: PolygonArea() = %v, want 1", offset, got)
+		}
+		reversed := []Point{shifted[3], shifted[2], shifted[1], shifted[0]}
+		if got := PolygonArea(reversed); got != -1 {
+			t.Errorf("offset %v: clockwise PolygonArea() = %v, want -1", offset, got)
+		}
+	}
+	if got := PolygonArea(nil); got != 0 {
+		t.Errorf("PolygonArea(nil) = %v, want 0", got)
+	}
+}
+
+func TestDelaunayReport(t *testing.T) {
+	// d is inside the circle through a, b and c, which has center 2, 1.5 and
+	// radius 2.5, so the long diagonal a-c is not Delaunay
+	a, b, c, d := Point{0, 0}, Point{2, -1}, Point{4, 0}, Point{2, 1}
+	report := DelaunayReport([]Triangle{{a, b, c}, {a, c, d}})
+	if report.Pairs != 1 || report.Violations != 1 || math.Abs(report.Worst-0.8) > 1e-12 || !report.WorstEdge.isEqual(Edge{a, c}) {
+		t.Errorf("DelaunayReport() = %+v, want 1 violation of 0.8 across %v", report, Edge{a, c})
+	}
+	if got, want := report.String(), "1 of 1 pairs violate the Delaunay condition, worst 0.8 across [(4, 0)-(0, 0)]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	points := append(randomPoints(103, 300), latticePoints(4)...)
+	triangles := mustTriangulate(t, points)
+	report = DelaunayReport(triangles)
+	if want := len(Edges(triangles)) - len(boundaryEdges(triangles)); report.Pairs != want || report.Violations != 0 || report.Worst != 0 {
+		t.Errorf("DelaunayReport() of a Delaunay triangulation = %+v, want %d pairs and no violations", report, want)
+	}
+	if got, want := report.String(), fmt.Sprintf("all %d pairs satisfy the Delaunay condition", report.Pairs); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
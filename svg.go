@@ -0,0 +1,127 @@
+package bowyer_watson
+
+import (
+	"html"
+	"io"
+	"math"
+)
+
+// Controls the appearance of the SVG written by WriteSVG
+// The zero value draws black outlines with no fill and no point markers. The
+// colors are escaped when written, so any string gives a well-formed document.
+type SVGOptions struct {
+	// Color of the triangle outlines, "black" if empty
+	Stroke string
+	// Width of the triangle outlines in screen pixels, 1 if zero
+	StrokeWidth float64
+	// Color inside the triangles, "none" if empty
+	Fill string
+	// Draw a marker at every vertex
+	Points bool
+	// Color of the vertex markers, the Stroke color if empty
+	PointFill string
+	// Radius of the vertex markers in point coordinates, 0.5% of the larger
+	// extent of the points if zero
+	PointRadius float64
+	// Draw the circumcircle of every non-degenerate triangle, which is useful
+	// when looking for Delaunay violations. The viewBox still only fits the
+	// vertices, so large circles are cut off.
+	Circumcircles bool
+	// Color of the circumcircles, "red" if empty
+	CircleStroke string
+}
+
+// Fraction of the larger extent of the points left empty around the drawing
+const svg_padding = 0.05
+
+// Given an array of triangles, render them as an SVG document
+// The viewBox is fitted to the extents of the vertices, with the Y axis pointing
+// up as it does for Points rather than down as is usual for SVG.
+// A nil options uses the defaults described on SVGOptions
+// Return: The first error from writing to w
+func WriteSVG(w io.Writer, triangles []Triangle, options *SVGOptions) error {
+	var opts SVGOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.Stroke == "" {
+		opts.Stroke = "black"
+	}
+	if opts.StrokeWidth == 0 {
+		opts.StrokeWidth = 1
+	}
+	if opts.Fill == "" {
+		opts.Fill = "none"
+	}
+	if opts.PointFill == "" {
+		opts.PointFill = opts.Stroke
+	}
+	if opts.CircleStroke == "" {
+		opts.CircleStroke = "red"
+	}
+	for _, color := range []*string{&opts.Stroke, &opts.Fill, &opts.PointFill, &opts.CircleStroke} {
+		*color = html.EscapeString(*color)
+	}
+
+	vertices := make([]Point, 0, 3*len(triangles))
+	for _, triangle := range triangles {
+		vertices = append(vertices, triangle.A, triangle.B, triangle.C)
+	}
+	min, max := BoundingBox(vertices)
+
+	extent := math.Max(max.X-min.X, max.Y-min.Y)
+	if extent == 0 {
+		extent = 1
+	}
+	if opts.PointRadius == 0 {
+		opts.PointRadius = extent * 0.005
+	}
+	padding := extent * svg_padding
+
+	ew := &errWriter{w: w}
+	// Y is flipped throughout, so the top of the viewBox is at -max.Y
+	ew.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"%g %g %g %g\">\n",
+		min.X-padding, -max.Y-padding, max.X-min.X+2*padding, max.Y-min.Y+2*padding)
+
+	ew.printf("<g stroke=\"%s\" stroke-width=\"%g\" fill=\"%s\" stroke-linejoin=\"round\">\n",
+		opts.Stroke, opts.StrokeWidth, opts.Fill)
+	for _, t := range triangles {
+		ew.printf("<polygon points=\"%g,%g %g,%g %g,%g\" vector-effect=\"non-scaling-stroke\"/>\n",
+			t.A.X, flipY(t.A.Y), t.B.X, flipY(t.B.Y), t.C.X, flipY(t.C.Y))
+	}
+	ew.printf("</g>\n")
+
+	if opts.Circumcircles {
+		ew.printf("<g stroke=\"%s\" stroke-width=\"%g\" fill=\"none\">\n", opts.CircleStroke, opts.StrokeWidth)
+		for i, circle := range Circumcircles(triangles) {
+			if triangles[i].Degenerate() {
+				continue
+			}
+			ew.printf("<circle cx=\"%g\" cy=\"%g\" r=\"%g\" vector-effect=\"non-scaling-stroke\"/>\n",
+				circle.Center.X, flipY(circle.Center.Y), circle.Radius)
+		}
+		ew.printf("</g>\n")
+	}
+
+	if opts.Points {
+		ew.printf("<g fill=\"%s\">\n", opts.PointFill)
+		seen := make(map[Point]bool, len(vertices))
+		for _, p := range vertices {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			ew.printf("<circle cx=\"%g\" cy=\"%g\" r=\"%g\"/>\n", p.X, flipY(p.Y), opts.PointRadius)
+		}
+		ew.printf("</g>\n")
+	}
+
+	ew.printf("</svg>\n")
+	return ew.err
+}
+
+// Given a Y coordinate, return it mirrored for SVG's downward Y axis
+// Subtracting from 0 rather than negating avoids printing -0
+func flipY(y float64) float64 {
+	return 0 - y
+}
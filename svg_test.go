@@ -0,0 +1,119 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{2, 0}, Point{2, 1}},
+		{Point{0, 0}, Point{2, 1}, Point{0, 1}},
+	}
+	var buffer bytes.Buffer
+	if err := WriteSVG(&buffer, triangles, &SVGOptions{Points: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		ViewBox string `xml:"viewBox,attr"`
+		Groups  []struct {
+			Polygons []struct{} `xml:"polygon"`
+			Circles  []struct{} `xml:"circle"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(buffer.Bytes(), &document); err != nil {
+		t.Fatalf("WriteSVG wrote invalid XML: %v\n%s", err, buffer.String())
+	}
+
+	// The points span 2 by 1, padded by 5% of 2, with Y flipped
+	if document.ViewBox != "-0.1 -1.1 2.2 1.2" {
+		t.Errorf("viewBox = %q, want \"-0.1 -1.1 2.2 1.2\"", document.ViewBox)
+	}
+	var polygons, circles int
+	for _, group := range document.Groups {
+		polygons += len(group.Polygons)
+		circles += len(group.Circles)
+	}
+	if polygons != 2 || circles != 4 {
+		t.Errorf("got %d polygons and %d point markers, want 2 and 4", polygons, circles)
+	}
+}
+
+func TestWriteSVGCircumcircles(t *testing.T) {
+	triangles := []Triangle{
+		{Point{0, 0}, Point{2, 0}, Point{2, 1}},
+		{Point{0, 0}, Point{2, 1}, Point{0, 1}},
+		{Point{0, 0}, Point{1, 0}, Point{2, 0}},
+	}
+	var buffer bytes.Buffer
+	if err := WriteSVG(&buffer, triangles, &SVGOptions{Circumcircles: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Circles []struct {
+			X float64 `xml:"cx,attr"`
+			Y float64 `xml:"cy,attr"`
+			R float64 `xml:"r,attr"`
+		} `xml:"g>circle"`
+	}
+	if err := xml.Unmarshal(buffer.Bytes(), &document); err != nil {
+		t.Fatalf("WriteSVG wrote invalid XML: %v\n%s", err, buffer.String())
+	}
+	// The degenerate triangle has no circle, and both others have the one through
+	// the corners of the rectangle
+	if len(document.Circles) != 2 {
+		t.Fatalf("got %d circles, want 2", len(document.Circles))
+	}
+	for _, circle := range document.Circles {
+		if circle.X != 1 || circle.Y != -0.5 || math.Abs(circle.R-math.Sqrt(1.25)) > 1e-12 {
+			t.Errorf("got a circle at %v, %v of radius %v, want 1, -0.5 and %v", circle.X, circle.Y, circle.R, math.Sqrt(1.25))
+		}
+	}
+}
+
+func TestWriteSVGEscapesColors(t *testing.T) {
+	triangles := []Triangle{{Point{0, 0}, Point{2, 0}, Point{2, 1}}}
+	colors := []string{`red" onload="alert(1)`, "<script>", "a & b", `'blue'`}
+	options := &SVGOptions{
+		Stroke:        colors[0],
+		Fill:          colors[1],
+		PointFill:     colors[2],
+		CircleStroke:  colors[3],
+		Points:        true,
+		Circumcircles: true,
+	}
+	var buffer bytes.Buffer
+	if err := WriteSVG(&buffer, triangles, options); err != nil {
+		t.Fatal(err)
+	}
+
+	var document struct {
+		Groups []struct {
+			Stroke string     `xml:"stroke,attr"`
+			Fill   string     `xml:"fill,attr"`
+			Other  []xml.Attr `xml:",any,attr"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(buffer.Bytes(), &document); err != nil {
+		t.Fatalf("WriteSVG wrote invalid XML: %v\n%s", err, buffer.String())
+	}
+	if len(document.Groups) != 3 {
+		t.Fatalf("got %d groups, want 3\n%s", len(document.Groups), buffer.String())
+	}
+	// Each color comes back whole, as the value of its attribute
+	want := [3][2]string{{colors[0], colors[1]}, {colors[3], "none"}, {"", colors[2]}}
+	for i, group := range document.Groups {
+		if group.Stroke != want[i][0] || group.Fill != want[i][1] {
+			t.Errorf("group %d has stroke %q and fill %q, want %q and %q", i, group.Stroke, group.Fill, want[i][0], want[i][1])
+		}
+		for _, attr := range group.Other {
+			if attr.Name.Local == "onload" {
+				t.Errorf("group %d has an onload attribute", i)
+			}
+		}
+	}
+}
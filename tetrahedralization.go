@@ -0,0 +1,171 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// Basic x,y,z coordinate
+type Point3D struct {
+	X, Y, Z float64
+}
+
+// Four points in space
+type Tetrahedron struct {
+	A, B, C, D Point3D
+}
+
+// Three points in space, one face of a Tetrahedron
+type face struct {
+	A, B, C Point3D
+}
+
+// Tetrahedron method
+// Determines if a given Point3D is contained within the tetrahedron's circumsphere
+// A point on the sphere counts as contained. The sign of the exact determinant is
+// used, so the result is never wrong because of round-off.
+// Return: True if point is contained, false if it is outside or the tetrahedron's
+// vertices are exactly coplanar
+func (t Tetrahedron) InSphere(p Point3D) bool {
+	var orientation = orient3d(t.A, t.B, t.C, t.D)
+	if orientation == 0 {
+		return false
+	}
+
+	var in = insphere(t.A, t.B, t.C, t.D, p)
+	if orientation < 0 {
+		in = -in
+	}
+	return in >= 0
+}
+
+// Tetrahedron method
+// Return: The volume of the tetrahedron
+func (t Tetrahedron) Volume() float64 {
+	return math.Abs(orient3d(t.A, t.B, t.C, t.D)) / 6
+}
+
+// Tetrahedron method
+// Return: True if p is one of the tetrahedron's vertices
+func (t Tetrahedron) containsPoint(p Point3D) bool {
+	return t.A == p || t.B == p || t.C == p || t.D == p
+}
+
+// Tetrahedron method
+// Return: The 4 faces of the tetrahedron
+func (t Tetrahedron) faces() [4]face {
+	return [4]face{{t.A, t.B, t.C}, {t.A, t.B, t.D}, {t.A, t.C, t.D}, {t.B, t.C, t.D}}
+}
+
+// face method
+// Return: The same face with its vertices ordered by X, then Y, then Z, so
+// that a face is equal to itself whichever order its vertices are in
+func (f face) normalized() face {
+	v := [3]Point3D{f.A, f.B, f.C}
+	sort.Slice(v[:], func(i, j int) bool { return point3DLess(v[i], v[j]) })
+	return face{v[0], v[1], v[2]}
+}
+
+// Given two points, return true if p comes before q ordering by X, then Y, then Z
+func point3DLess(p, q Point3D) bool {
+	if p.X != q.X {
+		return p.X < q.X
+	}
+	if p.Y != q.Y {
+		return p.Y < q.Y
+	}
+	return p.Z < q.Z
+}
+
+// How far the super tetrahedron extends past the points, as a multiple of the
+// largest side of their bounding box
+const super_tetrahedron_margin = 20
+
+// Given an array of points, return a tetrahedron that strictly contains all of them
+// As with ComputeSuperTriangle, the tetrahedron is built around the bounding box
+// of the points, and a unit box is used when the box has no extent.
+// Return: A super tetrahedron suitable for DelaunayTetrahedralization
+func ComputeSuperTetrahedron(points []Point3D) Tetrahedron {
+	var min, max Point3D
+	for i, p := range points {
+		if i == 0 {
+			min, max = p, p
+			continue
+		}
+		min = Point3D{math.Min(min.X, p.X), math.Min(min.Y, p.Y), math.Min(min.Z, p.Z)}
+		max = Point3D{math.Max(max.X, p.X), math.Max(max.Y, p.Y), math.Max(max.Z, p.Z)}
+	}
+
+	delta := math.Max(max.X-min.X, math.Max(max.Y-min.Y, max.Z-min.Z))
+	if delta == 0 {
+		delta = 1
+	}
+	size := super_tetrahedron_margin * delta
+
+	mid := Point3D{(min.X + max.X) / 2, (min.Y + max.Y) / 2, (min.Z + max.Z) / 2}
+	return Tetrahedron{
+		Point3D{mid.X + size, mid.Y + size, mid.Z + size},
+		Point3D{mid.X + size, mid.Y - size, mid.Z - size},
+		Point3D{mid.X - size, mid.Y + size, mid.Z - size},
+		Point3D{mid.X - size, mid.Y - size, mid.Z + size},
+	}
+}
+
+// Given an array of points, return an array of tetrahedra of their Delaunay
+// tetrahedralization
+// This is DelaunayTriangulation one dimension up: each point removes every
+// tetrahedron whose circumsphere contains it, and the cavity left behind is
+// filled with tetrahedra joining its boundary faces to the point.
+// Super tetrahedron is a tetrahedron that contains all the points, see
+// ComputeSuperTetrahedron. Points that appear more than once are only inserted
+// the first time they are seen.
+func DelaunayTetrahedralization(points []Point3D, super_tetrahedron Tetrahedron) []Tetrahedron {
+	tetrahedra := []Tetrahedron{super_tetrahedron}
+	inserted := make(map[Point3D]bool, len(points))
+
+	var faces []face
+	for _, p := range points {
+		if inserted[p] {
+			continue
+		}
+		inserted[p] = true
+
+		faces = faces[:0]
+		kept := tetrahedra[:0]
+		for _, tetrahedron := range tetrahedra {
+			if tetrahedron.InSphere(p) {
+				for _, f := range tetrahedron.faces() {
+					faces = append(faces, f)
+				}
+				continue
+			}
+			kept = append(kept, tetrahedron)
+		}
+		tetrahedra = kept
+
+		// A face shared by two bad tetrahedra is interior to the cavity, so only
+		// faces seen exactly once form its boundary
+		face_count := make(map[face]int, len(faces))
+		for _, f := range faces {
+			face_count[f.normalized()]++
+		}
+		for _, f := range faces {
+			if face_count[f.normalized()] == 1 {
+				tetrahedra = append(tetrahedra, Tetrahedron{f.A, f.B, f.C, p})
+			}
+		}
+	}
+
+	//Remove any tetrahedra using the Points of the supertetrahedron
+	kept := tetrahedra[:0]
+	for _, tetrahedron := range tetrahedra {
+		if tetrahedron.containsPoint(super_tetrahedron.A) ||
+			tetrahedron.containsPoint(super_tetrahedron.B) ||
+			tetrahedron.containsPoint(super_tetrahedron.C) ||
+			tetrahedron.containsPoint(super_tetrahedron.D) {
+			continue
+		}
+		kept = append(kept, tetrahedron)
+	}
+	return kept
+}
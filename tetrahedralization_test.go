@@ -0,0 +1,98 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Determines if the tetrahedra are non-degenerate, with no point strictly inside
+// any circumsphere, and have a total volume of volume if it is positive
+// Return: nil if they are, otherwise a description of the problem
+func checkTetrahedralization(tetrahedra []Tetrahedron, points []Point3D, volume float64) error {
+	var total float64
+	for _, tetrahedron := range tetrahedra {
+		orientation := orient3d(tetrahedron.A, tetrahedron.B, tetrahedron.C, tetrahedron.D)
+		if orientation == 0 {
+			return fmt.Errorf("%v is flat", tetrahedron)
+		}
+		total += tetrahedron.Volume()
+		for _, p := range points {
+			in := insphere(tetrahedron.A, tetrahedron.B, tetrahedron.C, tetrahedron.D, p)
+			if orientation < 0 {
+				in = -in
+			}
+			if in > 0 {
+				return fmt.Errorf("%v is strictly inside the circumsphere of %v", p, tetrahedron)
+			}
+		}
+	}
+	if volume > 0 && math.Abs(total-volume) > 1e-9*volume {
+		return fmt.Errorf("%d tetrahedra fill %v, want %v", len(tetrahedra), total, volume)
+	}
+	return nil
+}
+
+func TestDelaunayTetrahedralizationCube(t *testing.T) {
+	var cube []Point3D
+	for i := 0; i < 8; i++ {
+		cube = append(cube, Point3D{float64(i & 1), float64(i >> 1 & 1), float64(i >> 2 & 1)})
+	}
+	tetrahedra := DelaunayTetrahedralization(cube, ComputeSuperTetrahedron(cube))
+	if err := checkTetrahedralization(tetrahedra, cube, 1); err != nil {
+		t.Error(err)
+	}
+
+	var lattice []Point3D
+	for i := 0; i < 64; i++ {
+		lattice = append(lattice, Point3D{float64(i & 3), float64(i >> 2 & 3), float64(i >> 4 & 3)})
+	}
+	tetrahedra = DelaunayTetrahedralization(lattice, ComputeSuperTetrahedron(lattice))
+	if err := checkTetrahedralization(tetrahedra, lattice, 27); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDelaunayTetrahedralizationRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(20))
+	points := make([]Point3D, 200)
+	for i := range points {
+		points[i] = Point3D{r.Float64(), r.Float64(), r.Float64()}
+	}
+	tetrahedra := DelaunayTetrahedralization(points, ComputeSuperTetrahedron(points))
+	if len(tetrahedra) == 0 {
+		t.Fatal("no tetrahedra")
+	}
+	if err := checkTetrahedralization(tetrahedra, points, 0); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	tetrahedron := Tetrahedron{Point3D{0, 0, 0}, Point3D{1, 0, 0}, Point3D{0, 1, 0}, Point3D{0, 0, 1}}
+	mirrored := Tetrahedron{tetrahedron.A, tetrahedron.C, tetrahedron.B, tetrahedron.D}
+	tests := []struct {
+		p    Point3D
+		want bool
+	}{
+		{Point3D{0.3, 0.3, 0.3}, true},
+		{Point3D{1, 1, 1}, true},
+		{Point3D{2, 2, 2}, false},
+		{Point3D{1, 1, 1.01}, false},
+	}
+	for _, test := range tests {
+		for _, tet := range []Tetrahedron{tetrahedron, mirrored} {
+			if got := tet.InSphere(test.p); got != test.want {
+				t.Errorf("%v.InSphere(%v) = %v, want %v", tet, test.p, got, test.want)
+			}
+		}
+	}
+	flat := Tetrahedron{Point3D{0, 0, 0}, Point3D{1, 0, 0}, Point3D{0, 1, 0}, Point3D{1, 1, 0}}
+	if flat.InSphere(Point3D{0.5, 0.5, 0}) {
+		t.Error("a flat tetrahedron contains a point")
+	}
+	if got := tetrahedron.Volume(); math.Abs(got-1.0/6) > 1e-15 {
+		t.Errorf("Volume() = %v, want 1/6", got)
+	}
+}
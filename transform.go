@@ -0,0 +1,79 @@
+package bowyer_watson
+
+import "math"
+
+// An affine transform of the plane, taking (x, y) to
+// (m[0][0]*x + m[0][1]*y + m[0][2], m[1][0]*x + m[1][1]*y + m[1][2])
+type Matrix2x3 [2][3]float64
+
+// Return: The transform leaving every point where it is
+func IdentityMatrix() Matrix2x3 {
+	return Matrix2x3{{1, 0, 0}, {0, 1, 0}}
+}
+
+// Return: The transform rotating counter-clockwise about the origin by angle, in
+// radians
+func RotationMatrix(angle float64) Matrix2x3 {
+	sin, cos := math.Sincos(angle)
+	return Matrix2x3{{cos, -sin, 0}, {sin, cos, 0}}
+}
+
+// Return: The transform scaling X by sx and Y by sy about the origin
+func ScaleMatrix(sx, sy float64) Matrix2x3 {
+	return Matrix2x3{{sx, 0, 0}, {0, sy, 0}}
+}
+
+// Return: The transform moving every point by dx and dy
+func TranslationMatrix(dx, dy float64) Matrix2x3 {
+	return Matrix2x3{{1, 0, dx}, {0, 1, dy}}
+}
+
+// Matrix2x3 method
+// Return: The transformed point
+func (m Matrix2x3) Apply(p Point) Point {
+	return Point{
+		m[0][0]*p.X + m[0][1]*p.Y + m[0][2],
+		m[1][0]*p.X + m[1][1]*p.Y + m[1][2],
+	}
+}
+
+// Matrix2x3 method
+// Combines two transforms, so that RotationMatrix(a).Then(TranslationMatrix(x, y))
+// rotates and then translates
+// Return: The transform applying m and then next
+func (m Matrix2x3) Then(next Matrix2x3) Matrix2x3 {
+	var product Matrix2x3
+	for row := 0; row < 2; row++ {
+		for column := 0; column < 3; column++ {
+			product[row][column] = next[row][0]*m[0][column] + next[row][1]*m[1][column]
+		}
+		product[row][2] += next[row][2]
+	}
+	return product
+}
+
+// Given an array of points and a transform, return the transformed points
+// Return: The points in a new slice, in the same order
+func TransformPoints(points []Point, m Matrix2x3) []Point {
+	transformed := make([]Point, len(points))
+	for i, p := range points {
+		transformed[i] = m.Apply(p)
+	}
+	return transformed
+}
+
+// Given an array of triangles and a transform, return the transformed triangles
+// Rotations, translations and uniform scaling keep a Delaunay triangulation
+// Delaunay, up to round-off, so transforming the points and then triangulating
+// them gives the same triangles as triangulating and then transforming. Other
+// transforms, such as unequal scaling, keep it a valid triangulation but not in
+// general a Delaunay one, and a transform that reflects the plane reverses the
+// winding of every triangle.
+// Return: The triangles in a new slice, in the same order
+func TransformTriangles(triangles []Triangle, m Matrix2x3) []Triangle {
+	transformed := make([]Triangle, len(triangles))
+	for i, t := range triangles {
+		transformed[i] = Triangle{m.Apply(t.A), m.Apply(t.B), m.Apply(t.C)}
+	}
+	return transformed
+}
@@ -0,0 +1,75 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix2x3(t *testing.T) {
+	p := Point{3, 4}
+	tests := []struct {
+		name string
+		m    Matrix2x3
+		want Point
+	}{
+		{"identity", IdentityMatrix(), Point{3, 4}},
+		{"rotation", RotationMatrix(math.Pi / 2), Point{-4, 3}},
+		{"scale", ScaleMatrix(2, -1), Point{6, -4}},
+		{"translation", TranslationMatrix(-3, 1), Point{0, 5}},
+		{"rotate then translate", RotationMatrix(math.Pi / 2).Then(TranslationMatrix(-3, 1)), Point{-7, 4}},
+		{"translate then rotate", TranslationMatrix(-3, 1).Then(RotationMatrix(math.Pi / 2)), Point{-5, 0}},
+		{"scale then scale", ScaleMatrix(2, 3).Then(ScaleMatrix(0.5, 2)), Point{3, 24}},
+	}
+	for _, test := range tests {
+		if got := test.m.Apply(p); !nearlyEqual(got, test.want) {
+			t.Errorf("%s: Apply(%v) = %v, want %v", test.name, p, got, test.want)
+		}
+	}
+}
+
+func TestTransformAffineInvariance(t *testing.T) {
+	points := randomPoints(127, 400)
+	triangles, err := Triangulate(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		m    Matrix2x3
+	}{
+		{"rotation", RotationMatrix(0.7)},
+		{"scale", ScaleMatrix(1e3, 1e3)},
+		{"translation", TranslationMatrix(-40, 250)},
+		{"all three", ScaleMatrix(0.01, 0.01).Then(RotationMatrix(-2)).Then(TranslationMatrix(5, -5))},
+	}
+	for _, test := range tests {
+		// Both sides apply the same transform to the same points, so the vertices
+		// agree exactly and the edges can be compared as they are
+		transformed, err := Triangulate(TransformPoints(points, test.m))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		want := TransformTriangles(triangles, test.m)
+		if len(transformed) != len(want) {
+			t.Errorf("%s: got %d triangles, want %d", test.name, len(transformed), len(want))
+		} else if edge, ok := matchesReference(transformed, true, edgeSet(want)); !ok {
+			t.Errorf("%s: transforming first and last differ at %v", test.name, edge)
+		}
+	}
+
+	// Unequal scaling is not Delaunay in general, but it is still a triangulation of
+	// the transformed points, covering their hull, and a reflection reverses the winding
+	stretched := TransformTriangles(triangles, ScaleMatrix(5, 1))
+	if err := checkHullArea(stretched, TransformPoints(points, ScaleMatrix(5, 1))); err != nil {
+		t.Errorf("stretched: %v", err)
+	}
+	for i, triangle := range TransformTriangles(triangles, ScaleMatrix(-1, 1)) {
+		if triangle.Orientation() != -triangles[i].Orientation() {
+			t.Errorf("reflected %v is wound as %v", triangle, triangles[i])
+			break
+		}
+	}
+	if got := TransformPoints(points, IdentityMatrix()); &got[0] == &points[0] || got[5] != points[5] {
+		t.Error("TransformPoints with the identity did not copy the points")
+	}
+}
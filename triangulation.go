@@ -0,0 +1,316 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// A Delaunay triangulation that points can be added to one at a time
+// It always contains the super triangle's Points, which Triangles filters out
+type Triangulation struct {
+	super_triangle Triangle
+	opts           Options
+	circles        []circumTriangle
+	inserted       *nearSet
+
+	// Index of the circumcircles when Options.Grid is set, see insertIndexed
+	grid *circleGrid
+
+	// Scratch space for the edges of the cavity, and for the parallel and grid
+	// searches' results, reused between insertions
+	edges         []Edge
+	edge_count    map[Edge]int
+	bad           []bool
+	bad_positions []int
+}
+
+// Given a super triangle, return a triangulation containing only that triangle
+// Every point later inserted must lie inside the super triangle, see ComputeSuperTriangle
+func NewTriangulation(super_triangle Triangle) *Triangulation {
+	return newTriangulation(super_triangle, Options{})
+}
+
+// Given a super triangle and options, return a triangulation containing only that triangle
+// Only the options that affect insertion, such as Epsilon, are used
+func newTriangulation(super_triangle Triangle, opts Options) *Triangulation {
+	t := &Triangulation{}
+	t.reset(super_triangle, opts)
+	return t
+}
+
+// Triangulation method
+// Empties the triangulation so that it contains only the given super triangle,
+// keeping the memory it has already allocated
+func (t *Triangulation) reset(super_triangle Triangle, opts Options) {
+	t.super_triangle = super_triangle
+	t.opts = opts
+	t.circles = append(t.circles[:0], newCircumTriangle(super_triangle))
+	t.grid = nil
+
+	if t.inserted != nil && t.inserted.tolerance == math.Max(opts.Epsilon, 0) {
+		t.inserted.clear()
+	} else {
+		t.inserted = newNearSet(opts.Epsilon)
+	}
+}
+
+// Triangulation method
+// Return: The scratch map for counting cavity edges, emptied
+func (t *Triangulation) edgeCount() map[Edge]int {
+	if t.edge_count == nil {
+		t.edge_count = make(map[Edge]int)
+	}
+	for edge := range t.edge_count {
+		delete(t.edge_count, edge)
+	}
+	return t.edge_count
+}
+
+// Triangulation method
+// Adds a point with one step of the Bowyer-Watson algorithm: every triangle whose
+// circumcircle contains the point is removed, and the cavity left behind is
+// filled with triangles joining its boundary to the point.
+// A point that has already been inserted is ignored
+func (t *Triangulation) Insert(p Point) {
+	if !t.inserted.add(p) {
+		return
+	}
+	if t.grid != nil {
+		t.insertIndexed(p)
+		return
+	}
+
+	bad := t.findBad(p)
+
+	// Remove every triangle whose circumcircle contains the point, keeping
+	// the edges of the cavity they leave behind
+	t.edges = t.edges[:0]
+	kept := t.circles[:0]
+	for i, circle := range t.circles {
+		if bad != nil && bad[i] || bad == nil && t.isBad(circle, p) {
+			triangle := circle.triangle
+			t.edges = append(t.edges, Edge{triangle.A, triangle.B}, Edge{triangle.A, triangle.C}, Edge{triangle.B, triangle.C})
+			continue
+		}
+		kept = append(kept, circle)
+	}
+	t.circles = kept
+
+	// An edge shared by two bad triangles is interior to the cavity, so only
+	// edges seen exactly once form its boundary
+	edge_count := t.edgeCount()
+	for _, edge := range t.edges {
+		edge_count[edge.normalized()]++
+	}
+
+	for _, edge := range t.edges {
+		if edge_count[edge.normalized()] == 1 {
+			t.circles = append(t.circles, newCircumTriangle(Triangle{edge.A, edge.B, p}))
+		}
+	}
+}
+
+// Triangulation method
+// Determines if inserting p removes a triangle, using Options.Predicate if it is
+// set and the triangle's cached circumcircle otherwise
+// Return: True if the triangle is bad
+func (t *Triangulation) isBad(c circumTriangle, p Point) bool {
+	if t.opts.Predicate != nil {
+		return t.opts.Predicate(c.triangle, p)
+	}
+	return c.contains(p, t.opts.Epsilon)
+}
+
+// Triangulation method
+// When the parallel search is enabled and the triangulation is large enough,
+// tests every circumcircle against p using a pool of goroutines. A custom
+// Options.Predicate is never called concurrently, so it is always left to the
+// serial search.
+// Return: Whether each triangle's circumcircle contains p, or nil if the caller
+// should test them itself
+func (t *Triangulation) findBad(p Point) []bool {
+	threshold := t.opts.ParallelThreshold
+	if threshold <= 0 {
+		threshold = default_parallel_threshold
+	}
+	if !t.opts.Parallel || t.opts.Predicate != nil || len(t.circles) < threshold {
+		return nil
+	}
+
+	if cap(t.bad) < len(t.circles) {
+		t.bad = make([]bool, len(t.circles))
+	}
+	t.bad = t.bad[:len(t.circles)]
+
+	workers := runtime.NumCPU()
+	chunk := (len(t.circles) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(t.circles); start += chunk {
+		end := start + chunk
+		if end > len(t.circles) {
+			end = len(t.circles)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				t.bad[i] = t.isBad(t.circles[i], p)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return t.bad
+}
+
+// Triangulation method
+// Removes a previously inserted point, re-triangulating the hole it leaves behind
+// The triangles around the point form a star-shaped polygon, which is filled by
+// repeatedly cutting off a corner whose circumcircle holds none of the polygon's
+// other vertices, so the triangulation stays Delaunay.
+// Return: An error if the point was never inserted or is a super triangle Point
+func (t *Triangulation) Remove(p Point) error {
+	if t.super_triangle.ContainsPoint(p) {
+		return fmt.Errorf("bowyer_watson: cannot remove super triangle point %v", p)
+	}
+
+	// Walking each triangle around p counter-clockwise, the edge opposite p
+	// runs counter-clockwise around the hole
+	next := make(map[Point]Point)
+	kept := t.circles[:0]
+	for _, circle := range t.circles {
+		if !circle.triangle.ContainsPoint(p) {
+			kept = append(kept, circle)
+			continue
+		}
+
+		triangle := circle.triangle.ToCCW()
+		for triangle.A != p {
+			triangle = Triangle{triangle.B, triangle.C, triangle.A}
+		}
+		next[triangle.B] = triangle.C
+	}
+
+	if len(next) == 0 {
+		return fmt.Errorf("bowyer_watson: point %v is not in the triangulation", p)
+	}
+	t.circles = kept
+	t.inserted.remove(p)
+
+	// Start from the smallest vertex so the new triangles do not depend on map
+	// iteration order
+	first := true
+	var start Point
+	for q := range next {
+		if first || pointLess(q, start) {
+			start = q
+			first = false
+		}
+	}
+	polygon := []Point{start}
+	for q := next[start]; q != start && len(polygon) < len(next); q = next[q] {
+		polygon = append(polygon, q)
+	}
+
+	for len(polygon) > 3 {
+		ear := delaunayEar(polygon)
+		a, b, c := polygon[(ear+len(polygon)-1)%len(polygon)], polygon[ear], polygon[(ear+1)%len(polygon)]
+		t.circles = append(t.circles, newCircumTriangle(Triangle{a, b, c}))
+		polygon = append(polygon[:ear], polygon[ear+1:]...)
+	}
+	if len(polygon) == 3 {
+		t.circles = append(t.circles, newCircumTriangle(Triangle{polygon[0], polygon[1], polygon[2]}))
+	}
+
+	return nil
+}
+
+// Given a counter-clockwise polygon, choose a vertex that can be cut off
+// The preferred vertex is convex and the circumcircle through it and its two
+// neighbors holds no other vertex of the polygon. If round-off leaves no such
+// vertex, the first convex vertex is used.
+// Return: The index of the vertex
+func delaunayEar(polygon []Point) int {
+	convex := -1
+	for i := range polygon {
+		a, b, c := polygon[(i+len(polygon)-1)%len(polygon)], polygon[i], polygon[(i+1)%len(polygon)]
+		if Orient2D(a, b, c) <= 0 {
+			continue
+		}
+		if convex < 0 {
+			convex = i
+		}
+
+		empty := true
+		for j, q := range polygon {
+			if j == i || q == a || q == c {
+				continue
+			}
+			if IncircleDeterminant(a, b, c, q) > 0 {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			return i
+		}
+	}
+
+	if convex < 0 {
+		return 0
+	}
+	return convex
+}
+
+// Triangulation method
+// Determines if the triangles left once the super triangle is removed cover the
+// convex hull of the inserted points
+// A triangulation of n points, h of them on the boundary of their convex hull, has
+// 2n - h - 2 triangles. The triangles left are always part of one, so any missing
+// are thin triangles along the hull whose circumcircles reached the super triangle.
+// Return: True if no triangle is missing, or if the inserted points all lie on
+// one line so that there are none to miss
+func (t *Triangulation) complete(triangles []Triangle) bool {
+	var scratch hullScratch
+	return t.completeUsing(triangles, &scratch)
+}
+
+// The memory used by Triangulation.completeUsing, which a Triangulator keeps
+// between calls
+type hullScratch struct {
+	points, chain []Point
+}
+
+// Triangulation method
+// As complete, reusing the memory of scratch
+func (t *Triangulation) completeUsing(triangles []Triangle, scratch *hullScratch) bool {
+	scratch.points = t.inserted.appendPoints(scratch.points[:0])
+	if !hasNonCollinearTriple(scratch.points) {
+		return true
+	}
+	var count int
+	count, scratch.chain = hullPointCountInPlace(scratch.points, scratch.chain[:0])
+	return len(triangles) == 2*len(scratch.points)-count-2
+}
+
+// Triangulation method
+// Return: The triangles of the inserted points, without any that use the super
+// triangle's Points
+func (t *Triangulation) Triangles() []Triangle {
+	return RemoveSuperTriangle(t.AllTriangles(), t.super_triangle)
+}
+
+// Triangulation method
+// Unlike Triangles this keeps the triangles joined to the super triangle, which
+// cover the rest of the super triangle, see RemoveSuperTriangle
+// Return: Every triangle, including those using the super triangle's Points
+func (t *Triangulation) AllTriangles() []Triangle {
+	triangles := make([]Triangle, len(t.circles))
+	for i, circle := range t.circles {
+		triangles[i] = circle.triangle
+	}
+	return triangles
+}
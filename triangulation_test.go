@@ -0,0 +1,110 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRemoveMatchesFreshTriangulation(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		points := randomPoints(seed, 40)
+		super := ComputeSuperTriangle(points)
+
+		triangulation := NewTriangulation(super)
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		for _, p := range points[:10] {
+			if err := triangulation.Remove(p); err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+		}
+
+		fresh := NewTriangulation(super)
+		for _, p := range points[10:] {
+			fresh.Insert(p)
+		}
+
+		got, want := triangulation.Triangles(), fresh.Triangles()
+		SortTriangles(got)
+		SortTriangles(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("seed %d: after Remove got %d triangles, want %d", seed, len(got), len(want))
+		}
+	}
+}
+
+func TestRemoveCocircularIsDeterministic(t *testing.T) {
+	// Removing an interior lattice point leaves a hole whose vertices are
+	// cocircular in fours, so several triangulations of it are Delaunay
+	points := latticePoints(5)
+	super := ComputeSuperTriangle(points)
+
+	var first []Triangle
+	for run := 0; run < 20; run++ {
+		triangulation := NewTriangulation(super)
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		for _, p := range []Point{{2, 2}, {1, 1}, {3, 2}} {
+			if err := triangulation.Remove(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		triangles := triangulation.Triangles()
+		if err := Validate(triangles); err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(TotalArea(triangles)-16) > 1e-12 {
+			t.Fatalf("got area %v, want 16", TotalArea(triangles))
+		}
+		SortTriangles(triangles)
+		if run == 0 {
+			first = triangles
+		} else if !reflect.DeepEqual(triangles, first) {
+			t.Fatalf("run %d gave a different triangulation", run)
+		}
+	}
+}
+
+func TestRemoveErrors(t *testing.T) {
+	points := []Point{{0, 0}, {1, 0}, {0, 1}}
+	super := ComputeSuperTriangle(points)
+	triangulation := NewTriangulation(super)
+	for _, p := range points {
+		triangulation.Insert(p)
+	}
+	if err := triangulation.Remove(Point{5, 5}); err == nil {
+		t.Error("removing an absent point succeeded")
+	}
+	if err := triangulation.Remove(super.A); err == nil {
+		t.Error("removing a super triangle point succeeded")
+	}
+}
+
+func TestInsertMatchesBatch(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		points := randomPoints(seed, 200)
+		if seed%2 == 0 {
+			points = append(points, latticePoints(6)...)
+		}
+		super := ComputeSuperTriangle(points)
+
+		triangulation := NewTriangulation(super)
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		if got, want := triangulation.Triangles(), DelaunayTriangulation(points, super); !reflect.DeepEqual(got, want) {
+			t.Errorf("seed %d: Insert gave %d triangles, DelaunayTriangulation %d", seed, len(got), len(want))
+		}
+
+		// Inserting a point again changes nothing
+		before := triangulation.AllTriangles()
+		triangulation.Insert(points[0])
+		if after := triangulation.AllTriangles(); !reflect.DeepEqual(before, after) {
+			t.Errorf("seed %d: inserting %v again changed the triangulation", seed, points[0])
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package bowyer_watson
+
+// Triangulates point set after point set, reusing the memory of the previous
+// triangulation for the next, which saves allocation and garbage collection when
+// many similar sets are triangulated, such as the frames of an animation
+// The zero value is ready to use. A Triangulator must not be used by more than
+// one goroutine at a time.
+type Triangulator struct {
+	triangulation Triangulation
+	triangles     []Triangle
+
+	// Memory for checking that no hull triangle was lost, and for triangulating
+	// again when one was
+	hull   hullScratch
+	divide divideConquer
+}
+
+// Triangulator method
+// Triangulates the points as Triangulate does
+// The returned slice belongs to the Triangulator and is overwritten by the next
+// call, so copy it if it is needed for longer.
+// Return: The triangles, or an error describing why the points were rejected
+func (tr *Triangulator) Triangulate(points []Point) ([]Triangle, error) {
+	tr.Reset()
+	if err := ValidatePoints(points); err != nil {
+		return nil, err
+	}
+
+	tr.triangulation.reset(ComputeSuperTriangle(points), Options{})
+	for _, p := range points {
+		tr.triangulation.Insert(p)
+	}
+
+	for _, circle := range tr.triangulation.circles {
+		tr.triangles = append(tr.triangles, circle.triangle)
+	}
+	tr.triangles = RemoveSuperTriangle(tr.triangles, tr.triangulation.super_triangle)
+	if !tr.triangulation.completeUsing(tr.triangles, &tr.hull) {
+		rebuilt, err := tr.divide.triangulate(points, tr.triangles[:0])
+		if err != nil {
+			return nil, err
+		}
+		tr.triangles = rebuilt
+	}
+	return tr.triangles, nil
+}
+
+// Triangulator method
+// Forgets the previous triangulation, keeping its memory for the next one
+func (tr *Triangulator) Reset() {
+	tr.triangulation.reset(Triangle{}, Options{})
+	tr.triangles = tr.triangles[:0]
+}
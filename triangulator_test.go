@@ -0,0 +1,141 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Return: count sets of random points of about n points each, as the frames of
+// an animation would be
+func frames(count, n int) [][]Point {
+	sets := make([][]Point, count)
+	for i := range sets {
+		sets[i] = randomPoints(int64(i), n+i%7)
+	}
+	return sets
+}
+
+// Return: n points along a curve so flat in the middle that the circumcircles of
+// the triangles between them reach the super triangle, which loses the triangles
+// and makes Triangulate fall back to TriangulateDivideConquer
+func flatArc(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		x := float64(i) / float64(n-1)
+		points[i] = Point{x, -1e-6 * math.Pow(x-0.5, 4)}
+	}
+	return points
+}
+
+func TestTriangulatorMatchesTriangulate(t *testing.T) {
+	var triangulator Triangulator
+	for i, points := range append(frames(10, 300), latticePoints(6), flatArc(51), randomPoints(61, 20)) {
+		got, err := triangulator.Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := Triangulate(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("frame %d: got %d triangles, want %d", i, len(got), len(want))
+		}
+	}
+
+	if _, err := triangulator.Triangulate([]Point{{0, 0}, {1, 1}}); err == nil {
+		t.Error("Triangulator accepted 2 points")
+	}
+	if got, err := triangulator.Triangulate(latticePoints(3)); err != nil || len(got) != 8 {
+		t.Errorf("after an error got %d triangles, %v, want 8", len(got), err)
+	}
+}
+
+func TestTriangulatorReusesMemory(t *testing.T) {
+	sets := frames(100, 200)
+	var triangulator Triangulator
+	triangulator.Triangulate(sets[0])
+
+	i := 0
+	reused := testing.AllocsPerRun(100, func() {
+		triangulator.Triangulate(sets[i%len(sets)])
+		i++
+	})
+	fresh := testing.AllocsPerRun(100, func() {
+		Triangulate(sets[i%len(sets)])
+		i++
+	})
+	if reused >= fresh {
+		t.Errorf("Triangulator made %v allocations a frame, Triangulate %v", reused, fresh)
+	}
+
+	// Frames that are triangulated again reuse the memory of that too
+	arcs := [][]Point{flatArc(51), flatArc(53)}
+	for _, points := range arcs {
+		triangulation := NewTriangulation(ComputeSuperTriangle(points))
+		for _, p := range points {
+			triangulation.Insert(p)
+		}
+		if triangulation.complete(triangulation.Triangles()) {
+			t.Fatalf("%d points along the arc do not lose any hull triangles", len(points))
+		}
+	}
+	triangulator.Triangulate(arcs[1])
+	reused = testing.AllocsPerRun(100, func() {
+		triangulator.Triangulate(arcs[i%len(arcs)])
+		i++
+	})
+	fresh = testing.AllocsPerRun(100, func() {
+		Triangulate(arcs[i%len(arcs)])
+		i++
+	})
+	// Only the sorts allocate, the rest being room for the same sizes
+	if reused > 10 || reused >= fresh {
+		t.Errorf("Triangulator made %v allocations a frame falling back, Triangulate %v", reused, fresh)
+	}
+}
+
+func BenchmarkTriangulate100Frames(b *testing.B) {
+	sets := frames(100, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, points := range sets {
+			if _, err := Triangulate(points); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTriangulator100Frames(b *testing.B) {
+	sets := frames(100, 1000)
+	var triangulator Triangulator
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, points := range sets {
+			if _, err := triangulator.Triangulate(points); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTriangulatorFallback100Frames(b *testing.B) {
+	var sets [][]Point
+	for i := 0; i < 100; i++ {
+		sets = append(sets, flatArc(51+2*(i%2)))
+	}
+	var triangulator Triangulator
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, points := range sets {
+			if _, err := triangulator.Triangulate(points); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Returned (wrapped) by ValidatePoints when there are not enough points
+var ErrTooFewPoints = errors.New("bowyer_watson: too few points")
+
+// Returned (wrapped) by ValidatePoints when every point lies on a single line
+var ErrCollinearPoints = errors.New("bowyer_watson: points are collinear")
+
+// Returned (wrapped) by ValidatePoints when a coordinate is NaN or infinite
+var ErrNonFinite = errors.New("bowyer_watson: non-finite coordinate")
+
+// Given an array of points, determine if they can be triangulated
+// Every coordinate must be finite, at least three points are needed, and they
+// must not all lie on one line
+// Return: nil if the points are usable, otherwise an error wrapping one of
+// ErrNonFinite, ErrTooFewPoints or ErrCollinearPoints
+func ValidatePoints(points []Point) error {
+	for i, p := range points {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+			return fmt.Errorf("%w: point %d is (%v, %v)", ErrNonFinite, i, p.X, p.Y)
+		}
+	}
+	if len(points) < 3 {
+		return fmt.Errorf("%w: need at least 3 points, got %d", ErrTooFewPoints, len(points))
+	}
+	if !hasNonCollinearTriple(points) {
+		return fmt.Errorf("%w: all %d points lie on a single line", ErrCollinearPoints, len(points))
+	}
+	return nil
+}
+
+// Determine if any three of the points form a triangle with a non-zero area
+// Return: True if the points do not all lie on a single line
+func hasNonCollinearTriple(points []Point) bool {
+	if len(points) < 3 {
+		return false
+	}
+
+	first := points[0]
+	var second Point
+	found_second := false
+	for _, p := range points[1:] {
+		if !found_second {
+			if p != first {
+				second = p
+				found_second = true
+			}
+			continue
+		}
+		if Orient2D(first, second, p) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Given the triangles of a triangulation, check that it is a valid Delaunay
+// triangulation
+// No triangle may have zero area, no two triangles may overlap (sharing an edge
+// or a vertex is fine), and no triangle's circumcircle may strictly contain a
+// vertex of another triangle. The checks use the exact predicates, so a
+// triangulation is never rejected because of round-off.
+// Return: nil if the triangulation is valid, otherwise an error describing the
+// first problem found
+func Validate(triangles []Triangle) error {
+	oriented := make([]Triangle, len(triangles))
+	for i, triangle := range triangles {
+		if Orient2D(triangle.A, triangle.B, triangle.C) == 0 {
+			return fmt.Errorf("bowyer_watson: triangle %d %v has zero area", i, triangle)
+		}
+		oriented[i] = triangle.ToCCW()
+	}
+
+	// Sweep from left to right so that only triangles whose X extents overlap
+	// are compared
+	order := make([]int, len(oriented))
+	low := make([]float64, len(oriented))
+	high := make([]float64, len(oriented))
+	for i, triangle := range oriented {
+		order[i] = i
+		low[i] = math.Min(triangle.A.X, math.Min(triangle.B.X, triangle.C.X))
+		high[i] = math.Max(triangle.A.X, math.Max(triangle.B.X, triangle.C.X))
+	}
+	sort.Slice(order, func(a, b int) bool { return low[order[a]] < low[order[b]] })
+
+	for a, i := range order {
+		for _, j := range order[a+1:] {
+			if low[j] >= high[i] {
+				break
+			}
+			if overlap(oriented[i], oriented[j]) {
+				return fmt.Errorf("bowyer_watson: triangles %d %v and %d %v overlap", i, triangles[i], j, triangles[j])
+			}
+		}
+	}
+
+	vertices, _ := IndexTriangles(triangles)
+	sort.Slice(vertices, func(a, b int) bool { return pointLess(vertices[a], vertices[b]) })
+
+	for i, triangle := range oriented {
+		for _, p := range circumcircleCandidates(vertices, triangle) {
+			if triangle.ContainsPoint(p) {
+				continue
+			}
+			if IncircleDeterminant(triangle.A, triangle.B, triangle.C, p) > 0 {
+				return fmt.Errorf("bowyer_watson: vertex %v is inside the circumcircle of triangle %d %v", p, i, triangles[i])
+			}
+		}
+	}
+
+	return nil
+}
+
+// Given points sorted by X and then Y and a triangle, narrow the points down to
+// those that may be inside its circumcircle
+// Return: The points whose X is within the circle's reach, or all of them if the
+// triangle is degenerate
+func circumcircleCandidates(sorted []Point, triangle Triangle) []Point {
+	center := triangle.Circumcenter()
+	if math.IsNaN(center.X) || math.IsInf(center.X, 0) {
+		return sorted
+	}
+	// Padded so that round-off in the circumcenter cannot leave out a point the
+	// exact test would reject
+	reach := triangle.CircumRadius() * (1 + 1e-6)
+	first := sort.Search(len(sorted), func(k int) bool { return sorted[k].X >= center.X-reach })
+	last := sort.Search(len(sorted), func(k int) bool { return sorted[k].X > center.X+reach })
+	return sorted[first:last]
+}
+
+// Given two counter-clockwise triangles, determine if their interiors overlap
+// Two convex shapes are apart exactly when some edge of one of them has the
+// whole of the other on its outer side
+// Return: True if the interiors share any area
+func overlap(t1, t2 Triangle) bool {
+	for _, pair := range [2][2]Triangle{{t1, t2}, {t2, t1}} {
+		for _, edge := range pair[0].edges() {
+			other := pair[1]
+			if Orient2D(edge.A, edge.B, other.A) <= 0 &&
+				Orient2D(edge.A, edge.B, other.B) <= 0 &&
+				Orient2D(edge.A, edge.B, other.C) <= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidatePoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   error
+	}{
+		{"none", nil, ErrTooFewPoints},
+		{"one", []Point{{0, 0}}, ErrTooFewPoints},
+		{"two", []Point{{0, 0}, {1, 1}}, ErrTooFewPoints},
+		{"three collinear", []Point{{0, 0}, {1, 1}, {2, 2}}, ErrCollinearPoints},
+		{"three identical", []Point{{1, 1}, {1, 1}, {1, 1}}, ErrCollinearPoints},
+		{"nearly collinear", []Point{{0, 0}, {1, 1}, {2, 2 + 1e-15}}, nil},
+		{"three valid", []Point{{0, 0}, {1, 0}, {0, 1}}, nil},
+		{"NaN", []Point{{0, 0}, {1, 0}, {math.NaN(), 1}}, ErrNonFinite},
+		{"Inf", []Point{{0, 0}, {math.Inf(-1), 0}, {0, 1}}, ErrNonFinite},
+	}
+	for _, test := range tests {
+		err := ValidatePoints(test.points)
+		if test.want == nil {
+			if err != nil {
+				t.Errorf("%s: ValidatePoints() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: ValidatePoints() = %v, want %v", test.name, err, test.want)
+		}
+
+		triangles, err := Triangulate(test.points)
+		if !errors.Is(err, test.want) || triangles != nil {
+			t.Errorf("%s: Triangulate() = %v, %v, want nil, %v", test.name, triangles, err, test.want)
+		}
+	}
+}
+
+func TestValidatePointsMessage(t *testing.T) {
+	err := ValidatePoints([]Point{{0, 0}, {1, 1}})
+	if err == nil || err.Error() != "bowyer_watson: too few points: need at least 3 points, got 2" {
+		t.Errorf("ValidatePoints() = %v", err)
+	}
+}
+
+func TestValidatePointsNonFinite(t *testing.T) {
+	valid := []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}}
+	tests := []struct {
+		name    string
+		index   int
+		bad     Point
+		message string
+	}{
+		{"NaN X", 2, Point{math.NaN(), 1}, "bowyer_watson: non-finite coordinate: point 2 is (NaN, 1)"},
+		{"Inf Y", 3, Point{1, math.Inf(1)}, "bowyer_watson: non-finite coordinate: point 3 is (1, +Inf)"},
+	}
+	for _, test := range tests {
+		points := append([]Point(nil), valid...)
+		points[test.index] = test.bad
+		err := ValidatePoints(points)
+		if !errors.Is(err, ErrNonFinite) || err.Error() != test.message {
+			t.Errorf("%s: ValidatePoints() = %v, want %q", test.name, err, test.message)
+		}
+		if _, err := Triangulate(points); !errors.Is(err, ErrNonFinite) {
+			t.Errorf("%s: Triangulate() error = %v, want %v", test.name, err, ErrNonFinite)
+		}
+	}
+
+	if err := ValidatePoints(valid); err != nil {
+		t.Errorf("ValidatePoints(%v) = %v, want nil", valid, err)
+	}
+	if triangles, err := Triangulate(valid); err != nil || len(triangles) != 2 {
+		t.Errorf("Triangulate(%v) = %v, %v, want 2 triangles", valid, triangles, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	a, b, c, d := Point{0, 0}, Point{2, 0}, Point{2, 2}, Point{0, 2}
+	tests := []struct {
+		name      string
+		triangles []Triangle
+		valid     bool
+	}{
+		{"square", []Triangle{{a, b, c}, {a, c, d}}, true},
+		{"clockwise", []Triangle{{a, c, b}, {a, d, c}}, true},
+		{"empty", nil, true},
+		{"zero area", []Triangle{{a, b, Point{1, 0}}}, false},
+		{"overlap", []Triangle{{a, b, c}, {a, b, d}}, false},
+		{"repeated", []Triangle{{a, b, c}, {b, c, a}}, false},
+		{"not Delaunay", []Triangle{{a, Point{2, -1}, Point{4, 0}}, {a, Point{4, 0}, Point{2, 1}}}, false},
+	}
+	for _, test := range tests {
+		if err := Validate(test.triangles); (err == nil) != test.valid {
+			t.Errorf("%s: Validate() = %v, want valid %v", test.name, err, test.valid)
+		}
+	}
+}
+
+// Points are read from the data two bytes at a time, as a small signed X and Y, so
+// that collinear, cocircular and repeated points come up often
+func FuzzTriangulate(f *testing.F) {
+	f.Add([]byte{0, 0, 1, 0, 0, 1})
+	f.Add([]byte{0, 0, 2, 0, 2, 2, 0, 2, 1, 1})
+	f.Add([]byte{0, 0, 1, 1, 2, 2, 3, 3, 0, 3})
+	f.Add([]byte{250, 6, 3, 9, 3, 9, 128, 127, 0, 0, 7, 200, 100, 100})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var points []Point
+		for i := 0; i+1 < len(data) && len(points) < 64; i += 2 {
+			points = append(points, Point{float64(int8(data[i])), float64(int8(data[i+1]))})
+		}
+		triangles, err := Triangulate(points)
+		if ValidatePoints(points) != nil {
+			if err == nil {
+				t.Fatalf("Triangulate(%v) accepted invalid points", points)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("Triangulate(%v): %v", points, err)
+		}
+		if err := checkCoversHull(triangles, points); err != nil {
+			t.Fatalf("Triangulate(%v): %v", points, err)
+		}
+	})
+}
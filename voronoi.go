@@ -0,0 +1,217 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// The region of the plane closer to Site than to any other input point
+// Vertices are the corners of the cell in counter-clockwise order
+type VoronoiCell struct {
+	Site     Point
+	Vertices []Point
+}
+
+// Given an array of points, return the Voronoi cell of every distinct point
+// The Voronoi diagram is the dual of the Delaunay triangulation: the corners of a
+// cell are the circumcenters of the triangles that use its site as a vertex.
+// Cells of points on the convex hull are unbounded, so every cell is clipped to
+// the bounding box of the points. Cells are returned in the order their sites
+// first appear in points.
+// Super triangle is a triangle that contains all the points, see ComputeSuperTriangle
+func Voronoi(points []Point, super_triangle Triangle) []VoronoiCell {
+	min, max := BoundingBox(points)
+	return voronoiCells(points, super_triangle, min, max)
+}
+
+// Given an array of points, return the Voronoi cell of every distinct point
+// clipped to the rectangle between min and max
+func voronoiCells(points []Point, super_triangle Triangle, min, max Point) []VoronoiCell {
+	triangles := insertPoints(points, super_triangle, Options{})
+
+	// The super triangle keeps every site away from the boundary of the
+	// triangulation, so each site is surrounded by a closed fan of triangles
+	fans := make(map[Point][]Point, len(points))
+	for _, triangle := range triangles {
+		if triangle.Degenerate() {
+			continue
+		}
+		center := triangle.Circumcenter()
+		for _, vertex := range [3]Point{triangle.A, triangle.B, triangle.C} {
+			fans[vertex] = append(fans[vertex], center)
+		}
+	}
+
+	cells := make([]VoronoiCell, 0, len(fans))
+	seen := make(map[Point]bool, len(points))
+	for _, site := range points {
+		if seen[site] {
+			continue
+		}
+		seen[site] = true
+
+		corners := sortAround(site, fans[site])
+		cells = append(cells, VoronoiCell{site, clipPolygonToRect(corners, min, max)})
+	}
+
+	return cells
+}
+
+// Given a mesh and one of its vertices, return the Voronoi cell of that site alone
+// This finds the same cell as Voronoi without building the whole diagram. The
+// corners of the cell of an interior site are the circumcenters of the triangles
+// around it, found with AllTrianglesSharingVertex. A site on the boundary of the
+// mesh has an unbounded cell, which is found instead by clipping the bounding box
+// of the vertices with the bisector between the site and each of its neighbors.
+// Either way the cell is clipped to the bounding box, as Voronoi does.
+// Return: The corners of the cell in counter-clockwise order, or nil if site is not
+// a vertex of the mesh
+func VoronoiCellForSite(mesh *Mesh, site Point) []Point {
+	incident := mesh.AllTrianglesSharingVertex(site)
+	if len(incident) == 0 {
+		return nil
+	}
+	min, max := BoundingBox(mesh.Vertices)
+
+	// Each edge from the site is used by two of the triangles around it unless it
+	// is on the boundary
+	edge_count := make(map[Point]int, 2*len(incident))
+	var corners []Point
+	for _, index := range incident {
+		triangle := mesh.Triangles[index]
+		if !triangle.Degenerate() {
+			corners = append(corners, triangle.Circumcenter())
+		}
+		for _, vertex := range [3]Point{triangle.A, triangle.B, triangle.C} {
+			if vertex != site {
+				edge_count[vertex]++
+			}
+		}
+	}
+
+	bounded := true
+	for _, count := range edge_count {
+		if count != 2 {
+			bounded = false
+		}
+	}
+	if bounded {
+		return clipPolygonToRect(sortAround(site, corners), min, max)
+	}
+
+	cell := []Point{min, {max.X, min.Y}, max, {min.X, max.Y}}
+	for neighbor := range edge_count {
+		cell = clipCloserTo(cell, site, neighbor)
+	}
+	return cell
+}
+
+// Given a center and the corners of a convex polygon around it, order the corners
+// counter-clockwise, dropping any that repeat the previous corner
+func sortAround(center Point, corners []Point) []Point {
+	sort.Slice(corners, func(i, j int) bool {
+		return math.Atan2(corners[i].Y-center.Y, corners[i].X-center.X) <
+			math.Atan2(corners[j].Y-center.Y, corners[j].X-center.X)
+	})
+
+	// Cocircular points give several triangles with the same circumcenter
+	unique := corners[:0]
+	for _, corner := range corners {
+		if len(unique) > 0 && nearlyEqual(unique[len(unique)-1], corner) {
+			continue
+		}
+		unique = append(unique, corner)
+	}
+	if len(unique) > 1 && nearlyEqual(unique[0], unique[len(unique)-1]) {
+		unique = unique[:len(unique)-1]
+	}
+	return unique
+}
+
+// Determine if two points are the same up to floating point round-off
+func nearlyEqual(p, q Point) bool {
+	var scale = math.Max(1, math.Max(math.Max(math.Abs(p.X), math.Abs(p.Y)), math.Max(math.Abs(q.X), math.Abs(q.Y))))
+	return math.Abs(p.X-q.X) <= 1e-9*scale && math.Abs(p.Y-q.Y) <= 1e-9*scale
+}
+
+// Given a polygon, return the part of it inside the rectangle between min and max
+func clipPolygonToRect(polygon []Point, min, max Point) []Point {
+	return clipPolygon(polygon, []Point{min, {max.X, min.Y}, max, {min.X, max.Y}})
+}
+
+// Given a polygon and a convex counter-clockwise clipping polygon, return the part
+// of the first polygon inside the second
+func clipPolygon(polygon []Point, clip []Point) []Point {
+	for i := 0; i < len(clip) && len(polygon) > 0; i++ {
+		polygon = clipHalfPlane(polygon, clip[i], clip[(i+1)%len(clip)])
+	}
+	return polygon
+}
+
+// Given a polygon and a directed line through a and b, return the part of the
+// polygon to the left of the line
+// This is one step of Sutherland-Hodgman clipping
+func clipHalfPlane(polygon []Point, a, b Point) []Point {
+	inside := func(p Point) bool {
+		return Orient2D(a, b, p) >= 0
+	}
+	intersect := func(p, q Point) Point {
+		// Fraction of the way from p to q at which the line ab is crossed
+		var along = Triangle{a, b, p}.SignedArea() / (Triangle{a, b, p}.SignedArea() - Triangle{a, b, q}.SignedArea())
+		return Point{p.X + (q.X-p.X)*along, p.Y + (q.Y-p.Y)*along}
+	}
+
+	clipped := make([]Point, 0, len(polygon)+1)
+	for j, current := range polygon {
+		previous := polygon[(j+len(polygon)-1)%len(polygon)]
+		if inside(current) {
+			if !inside(previous) {
+				clipped = append(clipped, intersect(previous, current))
+			}
+			clipped = append(clipped, current)
+		} else if inside(previous) {
+			clipped = append(clipped, intersect(previous, current))
+		}
+	}
+	return clipped
+}
+
+// Given a polygon and two sites, return the part of the polygon closer to site
+// than to other
+func clipCloserTo(polygon []Point, site, other Point) []Point {
+	// Directed along the bisector so that site is on its left
+	mid := Edge{site, other}.Midpoint()
+	along := Point{site.Y - other.Y, other.X - site.X}
+	return clipHalfPlane(polygon, mid, mid.Add(along))
+}
+
+// Given a polygon, return its area using the shoelace formula
+// For the polygon returned by ConvexHull this is the area a triangulation of the
+// points should cover, see TotalArea
+// The corners are taken relative to the first, so that a polygon far from the
+// origin does not lose its area to cancellation
+// Return: Positive for a counter-clockwise polygon, negative for clockwise
+func PolygonArea(polygon []Point) float64 {
+	var area float64
+	for i := 1; i+1 < len(polygon); i++ {
+		p, q := polygon[i].Sub(polygon[0]), polygon[i+1].Sub(polygon[0])
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return area / 2
+}
+
+// Given a polygon with non-zero area, return its centroid
+// As in PolygonArea the corners are taken relative to the first, so that a polygon
+// far from the origin keeps its precision
+func polygonCentroid(polygon []Point) Point {
+	var x, y float64
+	origin := polygon[0]
+	for i := 1; i+1 < len(polygon); i++ {
+		p, q := polygon[i].Sub(origin), polygon[i+1].Sub(origin)
+		cross := p.X*q.Y - q.X*p.Y
+		x += (p.X + q.X) * cross
+		y += (p.Y + q.Y) * cross
+	}
+	area := PolygonArea(polygon)
+	return Point{origin.X + x/(6*area), origin.Y + y/(6*area)}
+}
@@ -0,0 +1,105 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVoronoiSquareIsPlusSign(t *testing.T) {
+	points := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	cells := Voronoi(points, ComputeSuperTriangle(points))
+	if len(cells) != 4 {
+		t.Fatalf("got %d cells, want 4", len(cells))
+	}
+
+	// Inside the bounding box, the cells are divided by the lines X = 0.5 and
+	// Y = 0.5, which cross at the middle of the square
+	center := Point{0.5, 0.5}
+	arms := map[Point]bool{{0.5, 0}: false, {1, 0.5}: false, {0.5, 1}: false, {0, 0.5}: false}
+	side := func(v, w float64) bool {
+		return math.Abs(v-w) < 1e-9 && (math.Abs(v) < 1e-9 || math.Abs(v-1) < 1e-9)
+	}
+	for i, cell := range cells {
+		if cell.Site != points[i] {
+			t.Errorf("cell %d has site %v, want %v", i, cell.Site, points[i])
+		}
+		if area := PolygonArea(cell.Vertices); math.Abs(area-0.25) > 1e-9 {
+			t.Errorf("cell of %v has area %v, want 0.25", cell.Site, area)
+		}
+
+		for j, p := range cell.Vertices {
+			q := cell.Vertices[(j+1)%len(cell.Vertices)]
+			if side(p.X, q.X) || side(p.Y, q.Y) {
+				continue
+			}
+			// Every other edge is one of the arms from the center
+			arm := q
+			if nearlyEqual(q, center) {
+				arm = p
+			} else if !nearlyEqual(p, center) {
+				t.Errorf("cell of %v has edge %v-%v off the plus sign", cell.Site, p, q)
+				continue
+			}
+			matched := false
+			for end := range arms {
+				if nearlyEqual(arm, end) {
+					arms[end], matched = true, true
+				}
+			}
+			if !matched {
+				t.Errorf("cell of %v has edge %v-%v off the plus sign", cell.Site, p, q)
+			}
+		}
+	}
+	for end, found := range arms {
+		if !found {
+			t.Errorf("no cell has the edge from %v to %v", center, end)
+		}
+	}
+}
+
+func TestVoronoiCellForSiteMatchesVoronoi(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		points := randomPoints(seed, 100)
+		if seed%2 == 0 {
+			points = append(points, latticePoints(2)...)
+		}
+		mesh, err := BuildMesh(points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, cell := range Voronoi(points, ComputeSuperTriangle(points)) {
+			got := VoronoiCellForSite(mesh, cell.Site)
+			area, want_area := PolygonArea(got), PolygonArea(cell.Vertices)
+			if math.Abs(area-want_area) > 1e-9 || area < 0 {
+				t.Fatalf("seed %d: cell of %v has area %v, Voronoi gives %v", seed, cell.Site, area, want_area)
+			}
+			if want_area > 1e-12 && !nearlyEqual(polygonCentroid(got), polygonCentroid(cell.Vertices)) {
+				t.Fatalf("seed %d: cell of %v is %v, Voronoi gives %v", seed, cell.Site, got, cell.Vertices)
+			}
+		}
+	}
+
+	mesh, err := BuildMesh(randomPoints(1, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := VoronoiCellForSite(mesh, Point{0.5, 2}); got != nil {
+		t.Errorf("VoronoiCellForSite of a point not in the mesh = %v, want nil", got)
+	}
+}
+
+func TestPolygonCentroidFarFromOrigin(t *testing.T) {
+	for _, offset := range []float64{0, 1e6, 1e9, -3e12} {
+		shift := func(x, y float64) Point { return Point{x + offset, y + offset} }
+		// An L of three unit squares, whose centroid is 5/6 from the corner on each axis
+		polygon := []Point{shift(0, 0), shift(2, 0), shift(2, 1), shift(1, 1), shift(1, 2), shift(0, 2)}
+		want := shift(5.0/6, 5.0/6)
+		// Within a few units in the last place of the coordinates
+		tolerance := 1e-15*math.Abs(offset) + 1e-15
+		if got := polygonCentroid(polygon); math.Abs(got.X-want.X) > tolerance || math.Abs(got.Y-want.Y) > tolerance {
+			t.Errorf("offset %v: polygonCentroid() = %v, want %v", offset, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"io"
+)
+
+// Wraps an io.Writer so that a sequence of writes can be checked for an error once
+// at the end. After the first failed write every later write is skipped.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// errWriter method
+// Writes formatted output, unless an earlier write has failed
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}